@@ -35,4 +35,25 @@ var (
 	TIER_LOW  = types.Tier("low")
 	TIER_MED  = types.Tier("med")
 	TIER_HIGH = types.Tier("high")
+
+	// cold start stream states, selected by the leading tritet of a stream
+	COLD_FREE   = types.Cold("free")   // not taken
+	COLD_CTB64  = types.Cold("ctb64")  // count code base64
+	COLD_OPB64  = types.Cold("opb64")  // op code base64
+	COLD_JSON   = types.Cold("json")   // json map event
+	COLD_MGPK1  = types.Cold("mgpk1")  // mgpk fixmap event
+	COLD_CBOR   = types.Cold("cbor")   // cbor map event
+	COLD_MGPK2  = types.Cold("mgpk2")  // mgpk big map event
+	COLD_CTOPB2 = types.Cold("ctopb2") // count code or op code base2
+
+	COLDS = []types.Cold{
+		COLD_FREE,
+		COLD_CTB64,
+		COLD_OPB64,
+		COLD_JSON,
+		COLD_MGPK1,
+		COLD_CBOR,
+		COLD_MGPK2,
+		COLD_CTOPB2,
+	}
 )
@@ -0,0 +1,17 @@
+package common
+
+import "fmt"
+
+// ShortageError reports that material was truncated rather than corrupt, and how many more
+// bytes are required before extraction can be retried
+type ShortageError struct {
+	Need int
+}
+
+func (e *ShortageError) Error() string {
+	return fmt.Sprintf("shortage: need %d more bytes", e.Need)
+}
+
+func NewShortageError(need int) *ShortageError {
+	return &ShortageError{Need: need}
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -265,6 +266,20 @@ func HexToUint32(hex []byte) (uint32, error) {
 	return uint32(val), nil
 }
 
+func Sniff(ims []byte) (types.Cold, error) {
+	if len(ims) == 0 {
+		return "", NewShortageError(1)
+	}
+
+	tritet := ims[0] >> 5
+	cold := COLDS[tritet]
+	if cold == COLD_FREE {
+		return "", fmt.Errorf("unexpected cold start tritet: %o", tritet)
+	}
+
+	return cold, nil
+}
+
 //nolint:gocritic
 func Smell(raw types.Raw) (types.Proto, types.Version, types.Kind, types.Size, *types.Version, error) {
 	re, err := ReVer()
@@ -274,6 +289,10 @@ func Smell(raw types.Raw) (types.Proto, types.Version, types.Kind, types.Size, *
 
 	match := re.FindSubmatch(raw)
 	if len(match) != 13 {
+		if len(raw) < SMELLSIZE {
+			return "", types.Version{}, "", 0, nil, NewShortageError(SMELLSIZE - len(raw))
+		}
+
 		return "", types.Version{}, "", 0, nil, fmt.Errorf("invalid version")
 	}
 
@@ -347,6 +366,10 @@ func Smell(raw types.Raw) (types.Proto, types.Version, types.Kind, types.Size, *
 	return "", types.Version{}, "", 0, nil, fmt.Errorf("invalid version")
 }
 
+// Sizeify serializes ked as kind and rewrites its version string with the serialized size. The version
+// string must declare version, which defaults to the current version, so callers sizing messages of other
+// versions pass the version explicitly.
+//
 //nolint:gocritic
 func Sizeify(ked types.Map, kind *types.Kind, version *types.Version) (
 	types.Raw,
@@ -383,6 +406,10 @@ func Sizeify(ked types.Map, kind *types.Kind, version *types.Version) (
 		return nil, "", "", types.Map{}, types.Version{}, fmt.Errorf("genus version mismatch")
 	}
 
+	if !slices.Contains(cesrgo.MAJORS, pvrsn.Major) {
+		return nil, "", "", types.Map{}, types.Version{}, fmt.Errorf("unsupported protocol version: %d.%d", pvrsn.Major, pvrsn.Minor)
+	}
+
 	if kind == nil {
 		kind = &knd
 	}
@@ -417,7 +444,7 @@ func Sizeify(ked types.Map, kind *types.Kind, version *types.Version) (
 	fore := offset[0]
 	back := offset[1]
 
-	vs, err := Versify(&proto, &pvrsn, kind, size, nil)
+	vs, err := Versify(&proto, &pvrsn, kind, size, gvrsn)
 	if err != nil {
 		return nil, "", "", types.Map{}, types.Version{}, err
 	}
@@ -459,8 +486,12 @@ func Versify(proto *types.Proto, pvrsn *types.Version, kind *types.Kind, size ty
 		return "", fmt.Errorf("kind not supported")
 	}
 
+	if pvrsn.Major == 1 {
+		return versify1(*proto, *pvrsn, *kind, size)
+	}
+
 	if pvrsn.Major < 2 || gvrsn.Major < 2 {
-		return "", fmt.Errorf("major versions must be >= 2")
+		return "", fmt.Errorf("major versions must be 1 or >= 2")
 	}
 
 	pvmaj, err := IntToB64(int(pvrsn.Major), 1)
@@ -491,6 +522,23 @@ func Versify(proto *types.Proto, pvrsn *types.Version, kind *types.Kind, size ty
 	return fmt.Sprintf("%s%s%s%s%s%s%s.", *proto, pvmaj, pvmin, gvmaj, gvmin, *kind, sz), nil
 }
 
+// versify1 produces the v1 form, which has hex fields and no genus version, e.g. KERI10JSON00011c_
+func versify1(proto types.Proto, pvrsn types.Version, kind types.Kind, size types.Size) (string, error) {
+	if kind == cesrgo.Kind_CESR {
+		return "", fmt.Errorf("native cesr serialization requires version 2")
+	}
+
+	if pvrsn.Minor > 0xf {
+		return "", fmt.Errorf("invalid v1 minor version: %d", pvrsn.Minor)
+	}
+
+	if size > 0xffffff {
+		return "", fmt.Errorf("size too large for v1 version string: %d", size)
+	}
+
+	return fmt.Sprintf("%s%x%x%s%06x%c", proto, pvrsn.Major, pvrsn.Minor, kind, size, VER1TERM), nil
+}
+
 //nolint:gocritic
 func Deversify(v string) (
 	types.Proto,
@@ -612,15 +660,21 @@ func Marshal(ked types.Map, kind *types.Kind) (types.Raw, error) {
 			return nil, err
 		}
 	case cesrgo.Kind_CBOR:
-		_, _ = cbor.Marshal(ked)
-
-		// need to implement MarshalCBOR for types.Map
-		return nil, fmt.Errorf("cbor not yet supported")
+		raw, err = cbor.Marshal(ked)
+		if err != nil {
+			return nil, err
+		}
 	case cesrgo.Kind_MGPK:
-		_, _ = msgpack.Marshal(ked)
+		buf := bytes.Buffer{}
+		enc := msgpack.NewEncoder(&buf)
+		// integers are packed in their smallest form, as other implementations do
+		enc.UseCompactInts(true)
+
+		if err := enc.Encode(ked); err != nil {
+			return nil, err
+		}
 
-		// need to investigate marshaling msgpack for types.Map
-		return nil, fmt.Errorf("mgpk not yet supported")
+		raw = buf.Bytes()
 	default:
 		return nil, fmt.Errorf("unsupported kind: %s", *kind)
 	}
@@ -638,13 +692,15 @@ func Unmarshal(kind types.Kind, raw types.Raw) (types.Map, error) {
 			return types.Map{}, err
 		}
 	case cesrgo.Kind_CBOR:
-		_ = cbor.Unmarshal(raw, &ked)
-
-		return types.Map{}, fmt.Errorf("cbor not yet supported")
+		err := cbor.Unmarshal(raw, &ked)
+		if err != nil {
+			return types.Map{}, err
+		}
 	case cesrgo.Kind_MGPK:
-		_ = msgpack.Unmarshal(raw, &ked)
-
-		return types.Map{}, fmt.Errorf("mgpk not yet supported")
+		err := msgpack.Unmarshal(raw, &ked)
+		if err != nil {
+			return types.Map{}, err
+		}
 	default:
 		return types.Map{}, fmt.Errorf("unsupported kind: %s", kind)
 	}
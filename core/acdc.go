@@ -0,0 +1,687 @@
+package cesr
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jasoncolburne/cesrgo"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// ACDCFields lists the fields a credential may have, in order, by protocol major version and ilk.
+// Version 1 credentials have no ilk. Version 2 section messages carry a single section.
+var ACDCFields = map[uint32]map[types.Ilk][]string{
+	cesrgo.VERSION_1_0.Major: {
+		"": {"v", "d", "u", "i", "ri", "s", "a", "A", "e", "r"},
+	},
+	cesrgo.VERSION_2_0.Major: {
+		cesrgo.Ilk_ACM: {"v", "t", "d", "u", "i", "rd", "s", "a", "A", "e", "r"},
+		cesrgo.Ilk_SCH: {"v", "t", "d", "s"},
+		cesrgo.Ilk_ATT: {"v", "t", "d", "a"},
+		cesrgo.Ilk_EDG: {"v", "t", "d", "e"},
+		cesrgo.Ilk_RUL: {"v", "t", "d", "r"},
+	},
+}
+
+// ACDCRequired lists the fields every credential of an ilk must have, by protocol major version
+var ACDCRequired = map[uint32]map[types.Ilk][]string{
+	cesrgo.VERSION_1_0.Major: {
+		"": {"v", "d", "i", "s"},
+	},
+	cesrgo.VERSION_2_0.Major: {
+		cesrgo.Ilk_ACM: {"v", "t", "d", "i", "s"},
+		cesrgo.Ilk_SCH: {"v", "t", "d", "s"},
+		cesrgo.Ilk_ATT: {"v", "t", "d", "a"},
+		cesrgo.Ilk_EDG: {"v", "t", "d", "e"},
+		cesrgo.Ilk_RUL: {"v", "t", "d", "r"},
+	},
+}
+
+// the label of the SAID of an expanded schema, which is a JSON schema
+const schemaSaidLabel = "$id"
+
+// SerderACDC is a Sadder for ACDC credentials. The schema, attribute, edge and rule sections may each
+// be expanded into a block or compacted to the block's SAID.
+type SerderACDC struct {
+	Sadder
+}
+
+// ACDCSection is a section of a credential. Block is nil when the section is compacted to its SAID.
+type ACDCSection struct {
+	Saider *Saider
+	Block  *types.Map
+}
+
+// NewSerderACDC loads a credential from raw or ked. When saidify is set, the SAID of every block is
+// computed (ked), innermost first, or verified (raw). Each SAID, the credential's included, is that of
+// the most compact form of its block, so it is the same however much of the block is disclosed.
+func NewSerderACDC(
+	code *types.Code,
+	raw *types.Raw,
+	ked *types.Map,
+	kind *types.Kind,
+	saidify bool,
+) (*SerderACDC, error) {
+	if kind != nil && *kind == cesrgo.Kind_CESR {
+		return nil, fmt.Errorf("unsupported kind for credential: %s", *kind)
+	}
+
+	var (
+		sadder *Sadder
+		err    error
+	)
+
+	if raw != nil {
+		if sadder, err = newSadder(code, raw, ked, kind, nil); err != nil {
+			return nil, err
+		}
+
+		if saidify {
+			c := &compactor{kind: sadder.GetKind()}
+
+			_, compact, err := c.fields(sadder.GetKed(), true)
+			if err != nil {
+				return nil, err
+			}
+
+			saider, err := acdcSaider(compact, sadder.GetSaider().GetCode(), sadder.GetKind())
+			if err != nil {
+				return nil, err
+			}
+
+			if saider.GetCode() != sadder.GetSaider().GetCode() || !slices.Equal(saider.GetRaw(), sadder.GetSaider().GetRaw()) {
+				return nil, fmt.Errorf("saider mismatch")
+			}
+		}
+	} else {
+		var saider *Saider
+		if saidify && ked != nil {
+			c := &compactor{code: codex.Blake3_256, kind: cesrgo.Kind_JSON, saidify: true}
+			if code != nil {
+				c.code = *code
+			}
+
+			if kind != nil {
+				c.kind = *kind
+			}
+
+			expanded, compact, err := c.fields(*ked, true)
+			if err != nil {
+				return nil, err
+			}
+
+			if saider, err = acdcSaider(compact, c.code, c.kind); err != nil {
+				return nil, err
+			}
+
+			qb64, err := saider.Qb64()
+			if err != nil {
+				return nil, err
+			}
+
+			expanded.Set("d", string(qb64))
+			ked = &expanded
+		}
+
+		if sadder, err = newSadder(code, raw, ked, kind, nil); err != nil {
+			return nil, err
+		}
+
+		sadder.saider = saider
+	}
+
+	s := &SerderACDC{Sadder: *sadder}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SerderACDC) validate() error {
+	if s.GetProto() != cesrgo.Proto_ACDC {
+		return fmt.Errorf("unexpected protocol for credential: %s", s.GetProto())
+	}
+
+	major := s.GetVersion().Major
+
+	fieldsByIlk, ok := ACDCFields[major]
+	if !ok {
+		return fmt.Errorf("unsupported version: %d.%d", major, s.GetVersion().Minor)
+	}
+
+	ilk := s.Ilk()
+
+	fields, ok := fieldsByIlk[ilk]
+	if !ok {
+		return fmt.Errorf("unsupported ilk: %s", ilk)
+	}
+
+	// present fields must appear in the order of the layout
+	keys := s.GetKed().Keys()
+	position := 0
+	for _, key := range keys {
+		offset := slices.Index(fields[position:], key)
+		if offset < 0 {
+			return fmt.Errorf("invalid fields for credential: expected a subsequence of %v, got %v", fields, keys)
+		}
+
+		position += offset + 1
+	}
+
+	for _, field := range ACDCRequired[major][ilk] {
+		if !slices.Contains(keys, field) {
+			return fmt.Errorf("field not present: %s", field)
+		}
+	}
+
+	if slices.Contains(keys, "a") && slices.Contains(keys, "A") {
+		return fmt.Errorf("credential has both attributes and aggregate")
+	}
+
+	return nil
+}
+
+func (s *SerderACDC) field(label string) (any, error) {
+	value, ok := s.ked.Get(label)
+	if !ok {
+		return nil, fmt.Errorf("field not present: %s", label)
+	}
+
+	return value, nil
+}
+
+func (s *SerderACDC) stringField(label string) (string, error) {
+	value, err := s.field(label)
+	if err != nil {
+		return "", err
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("field %s is not a string: %T", label, value)
+	}
+
+	return str, nil
+}
+
+// Ilk is empty for version 1 credentials, which have none
+func (s *SerderACDC) Ilk() types.Ilk {
+	ilk, _ := s.stringField("t")
+	return types.Ilk(ilk)
+}
+
+func (s *SerderACDC) Said() string {
+	said, _ := s.stringField("d")
+	return said
+}
+
+// Issuer returns the prefix of the issuer
+func (s *SerderACDC) Issuer() string {
+	issuer, _ := s.stringField("i")
+	return issuer
+}
+
+func (s *SerderACDC) Prefixer() (*Prefixer, error) {
+	issuer, err := s.stringField("i")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrefixer(options.WithQb64(types.Qb64(issuer)))
+}
+
+// Noncer returns the salty nonce that blinds the credential
+func (s *SerderACDC) Noncer() (*Noncer, error) {
+	uuid, err := s.stringField("u")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNoncer(nil, options.WithQb64(types.Qb64(uuid)))
+}
+
+// Registry returns the identifier of the registry tracking the credential's status, ri in version 1
+// and rd in version 2
+func (s *SerderACDC) Registry() (*Prefixer, error) {
+	label := "rd"
+	if s.GetVersion().Major == cesrgo.VERSION_1_0.Major {
+		label = "ri"
+	}
+
+	registry, err := s.stringField(label)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrefixer(options.WithQb64(types.Qb64(registry)))
+}
+
+func (s *SerderACDC) Schema() (*ACDCSection, error) {
+	return s.section("s", schemaSaidLabel)
+}
+
+func (s *SerderACDC) Attributes() (*ACDCSection, error) {
+	return s.section("a", "d")
+}
+
+func (s *SerderACDC) Edges() (*ACDCSection, error) {
+	return s.section("e", "d")
+}
+
+func (s *SerderACDC) Rules() (*ACDCSection, error) {
+	return s.section("r", "d")
+}
+
+// Aggregate returns the identifier of the attribute aggregate and the blocks disclosed, which are
+// verified against it
+func (s *SerderACDC) Aggregate() (*Diger, []types.Map, error) {
+	value, err := s.field("A")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if agid, ok := value.(string); ok {
+		diger, err := NewDiger(nil, options.WithQb64(types.Qb64(agid)))
+		return diger, nil, err
+	}
+
+	list := listOf(value)
+	if list == nil {
+		return nil, nil, fmt.Errorf("field A is neither an identifier nor an aggregate: %T", value)
+	}
+
+	return VerifyAggregate(list, s.GetKind())
+}
+
+// DiscloseAggregate replaces the attribute aggregate with aggregate, a disclosure of it, which must
+// have the same identifier. The SAID of the credential is unchanged.
+func (s *SerderACDC) DiscloseAggregate(aggregate []any) (*SerderACDC, error) {
+	diger, _, err := s.Aggregate()
+	if err != nil {
+		return nil, err
+	}
+
+	disclosed, _, err := VerifyAggregate(aggregate, s.GetKind())
+	if err != nil {
+		return nil, err
+	}
+
+	if disclosed.GetCode() != diger.GetCode() || !slices.Equal(disclosed.GetRaw(), diger.GetRaw()) {
+		return nil, fmt.Errorf("aggregate identifier mismatch")
+	}
+
+	ked := s.GetKed().Clone()
+	ked.Set("A", aggregate)
+
+	return s.reserialize(ked)
+}
+
+// Issuee returns the prefix of the issuee, from an expanded attribute section
+func (s *SerderACDC) Issuee() (*Prefixer, error) {
+	attributes, err := s.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	if attributes.Block == nil {
+		return nil, fmt.Errorf("attributes are compact")
+	}
+
+	issuee, ok := attributes.Block.Get("i")
+	if !ok {
+		return nil, fmt.Errorf("credential has no issuee")
+	}
+
+	qb64, ok := issuee.(string)
+	if !ok {
+		return nil, fmt.Errorf("issuee is not a string: %T", issuee)
+	}
+
+	return NewPrefixer(options.WithQb64(types.Qb64(qb64)))
+}
+
+// section reads a section that is either compact, a SAID, or expanded, a block holding its SAID at
+// label
+func (s *SerderACDC) section(field, label string) (*ACDCSection, error) {
+	value, err := s.field(field)
+	if err != nil {
+		return nil, err
+	}
+
+	section := &ACDCSection{}
+
+	said, ok := value.(string)
+	if !ok {
+		block, ok := value.(types.Map)
+		if !ok {
+			return nil, fmt.Errorf("field %s is neither a said nor a block: %T", field, value)
+		}
+
+		blockSaid, ok := block.Get(label)
+		if !ok {
+			return nil, fmt.Errorf("block %s has no said", field)
+		}
+
+		if said, ok = blockSaid.(string); !ok {
+			return nil, fmt.Errorf("said of block %s is not a string: %T", field, blockSaid)
+		}
+
+		section.Block = &block
+	}
+
+	if section.Saider, err = NewSaider(nil, nil, nil, options.WithQb64(types.Qb64(said))); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// Compact returns the most compact form of the credential, with each section replaced by its SAID.
+// Since every SAID is computed over the most compact form of its block, the SAID is unchanged.
+func (s *SerderACDC) Compact() (*SerderACDC, error) {
+	c := &compactor{kind: s.GetKind()}
+
+	_, compact, err := c.fields(s.GetKed(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reserialize(compact)
+}
+
+// Expand discloses blocks of the credential, replacing each SAID that one of blocks hashes to with the
+// block. A disclosed block may itself be compact, with its own nested blocks among those disclosed.
+// Every block must be verified against the SAID it replaces.
+func (s *SerderACDC) Expand(blocks ...types.Map) (*SerderACDC, error) {
+	c := &compactor{kind: s.GetKind()}
+
+	disclosed := map[string]types.Map{}
+	for _, block := range blocks {
+		label := "d"
+		if _, ok := block.Get(schemaSaidLabel); ok {
+			label = schemaSaidLabel
+		}
+
+		value, _ := block.Get(label)
+
+		said, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("disclosed block has no said")
+		}
+
+		if _, _, err := c.value(block, label, label == schemaSaidLabel); err != nil {
+			return nil, err
+		}
+
+		disclosed[said] = block
+	}
+
+	used := map[string]bool{}
+	expanded := expandBlocks(s.GetKed(), disclosed, used, true)
+
+	for said := range disclosed {
+		if !used[said] {
+			return nil, fmt.Errorf("disclosed block %s does not replace a said of the credential", said)
+		}
+	}
+
+	return s.reserialize(expanded)
+}
+
+// reserialize loads ked, which keeps the SAID of the credential, as a credential of the same kind
+func (s *SerderACDC) reserialize(ked types.Map) (*SerderACDC, error) {
+	kind := s.GetKind()
+
+	raw, _, _, _, _, err := s.exhale(ked, &kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSerderACDC(nil, &raw, nil, nil, true)
+}
+
+// the fields of a credential holding sections
+var acdcSections = []string{"s", "a", "A", "e", "r"}
+
+// the fields of nested blocks holding identifiers or SAIDs of other things than blocks
+var acdcReferences = []string{"d", "u", "i", "n", "s"}
+
+// compactor computes the SAIDs of the blocks of a credential over their most compact forms, in which
+// each nested block is replaced by its SAID. A block is a map holding a string at d, or at $id for an
+// expanded schema. When saidify is set each SAID is computed and set, otherwise each is verified.
+type compactor struct {
+	code    types.Code
+	kind    types.Kind
+	saidify bool
+}
+
+// fields returns copies of m, expanded and in its most compact form. Of a credential, only sections
+// are walked; an expanded schema is a JSON schema, and is not walked.
+func (c *compactor) fields(m types.Map, top bool) (types.Map, types.Map, error) {
+	expanded := types.NewMap()
+	compact := types.NewMap()
+
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+
+		if top && !slices.Contains(acdcSections, key) {
+			expanded.Set(key, value)
+			compact.Set(key, value)
+
+			continue
+		}
+
+		// a disclosed aggregate compacts to its identifier
+		if list := listOf(value); top && key == "A" && list != nil {
+			diger, _, err := c.aggregate(list)
+			if err != nil {
+				return types.Map{}, types.Map{}, err
+			}
+
+			agid, err := diger.Qb64()
+			if err != nil {
+				return types.Map{}, types.Map{}, err
+			}
+
+			expanded.Set(key, value)
+			compact.Set(key, string(agid))
+
+			continue
+		}
+
+		label := "d"
+		schema := top && key == "s"
+		if schema {
+			label = schemaSaidLabel
+		}
+
+		exp, cmp, err := c.value(value, label, schema)
+		if err != nil {
+			return types.Map{}, types.Map{}, err
+		}
+
+		expanded.Set(key, exp)
+		compact.Set(key, cmp)
+	}
+
+	return expanded, compact, nil
+}
+
+// value returns copies of value, expanded and in its most compact form. A block compacts to its SAID.
+func (c *compactor) value(value any, label string, schema bool) (any, any, error) {
+	switch v := value.(type) {
+	case types.Map:
+		expanded, compact := v.Clone(), v.Clone()
+		if !schema {
+			var err error
+			if expanded, compact, err = c.fields(v, false); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if said, ok := v.Get(label); !ok {
+			return expanded, compact, nil
+		} else if _, ok := said.(string); !ok {
+			return expanded, compact, nil
+		}
+
+		said, err := c.said(compact, label)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		expanded.Set(label, said)
+
+		return expanded, said, nil
+	case types.List:
+		return c.list(v)
+	case []any:
+		return c.list(v)
+	default:
+		return value, value, nil
+	}
+}
+
+func (c *compactor) list(values []any) (any, any, error) {
+	expanded := make([]any, len(values))
+	compact := make([]any, len(values))
+
+	for i, value := range values {
+		var err error
+		if expanded[i], compact[i], err = c.value(value, "d", false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return expanded, compact, nil
+}
+
+// said computes the SAID of a block in its most compact form, or verifies the one it holds
+func (c *compactor) said(compact types.Map, label string) (string, error) {
+	value, _ := compact.Get(label)
+	stated, _ := value.(string)
+
+	code := c.code
+	if !c.saidify {
+		saider, err := NewSaider(nil, nil, nil, options.WithQb64(types.Qb64(stated)))
+		if err != nil {
+			return "", err
+		}
+
+		code = saider.GetCode()
+	}
+
+	saider, err := deriveBlock(compact, label, code, c.kind)
+	if err != nil {
+		return "", err
+	}
+
+	qb64, err := saider.Qb64()
+	if err != nil {
+		return "", err
+	}
+
+	if !c.saidify && string(qb64) != stated {
+		return "", fmt.Errorf("saider mismatch: block %s", stated)
+	}
+
+	return string(qb64), nil
+}
+
+// expandBlocks copies m, replacing each SAID of a section or nested block in disclosed with its block,
+// itself expanded, and noting it in used
+func expandBlocks(m types.Map, disclosed map[string]types.Map, used map[string]bool, top bool) types.Map {
+	expanded := types.NewMap()
+
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+
+		walk := slices.Contains(acdcSections, key)
+		if !top {
+			walk = !slices.Contains(acdcReferences, key)
+		}
+
+		if walk {
+			// an expanded schema is a JSON schema, and is not walked
+			value = expandValue(value, disclosed, used, !(top && key == "s"))
+		}
+
+		expanded.Set(key, value)
+	}
+
+	return expanded
+}
+
+func expandValue(value any, disclosed map[string]types.Map, used map[string]bool, nested bool) any {
+	switch v := value.(type) {
+	case string:
+		block, ok := disclosed[v]
+		if !ok {
+			return v
+		}
+
+		used[v] = true
+		if !nested {
+			return block
+		}
+
+		return expandBlocks(block, disclosed, used, false)
+	case types.Map:
+		if !nested {
+			return v
+		}
+
+		return expandBlocks(v, disclosed, used, false)
+	case types.List:
+		return expandList(v, disclosed, used)
+	case []any:
+		return expandList(v, disclosed, used)
+	default:
+		return value
+	}
+}
+
+func expandList(values []any, disclosed map[string]types.Map, used map[string]bool) []any {
+	expanded := make([]any, len(values))
+	for i, value := range values {
+		expanded[i] = expandValue(value, disclosed, used, true)
+	}
+
+	return expanded
+}
+
+// listOf returns value as a list, or nil if it is not one
+func listOf(value any) []any {
+	switch v := value.(type) {
+	case types.List:
+		return v
+	case []any:
+		return v
+	default:
+		return nil
+	}
+}
+
+// acdcSaider computes the SAID of a credential over its most compact form, sized by its own version
+// string
+func acdcSaider(compact types.Map, code types.Code, kind types.Kind) (*Saider, error) {
+	sadder, err := newSadder(&code, nil, &compact, &kind, []string{"d"})
+	if err != nil {
+		return nil, err
+	}
+
+	return sadder.GetSaider(), nil
+}
+
+// deriveBlock computes the SAID of block, serialized as kind with label replaced by a dummy
+func deriveBlock(block types.Map, label string, code types.Code, kind types.Kind) (*Saider, error) {
+	digest, _, err := derive(&block, &code, &kind, []string{label}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSaider(nil, nil, nil, options.WithCode(code), options.WithRaw(digest))
+}
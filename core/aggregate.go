@@ -0,0 +1,173 @@
+package cesr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jasoncolburne/cesrgo"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// Aggregator builds the selectively disclosable attribute aggregate of a credential, its A field. Each
+// attribute is blinded in its own block by a salty nonce, u, and identified by the block's SAID, d.
+// The aggregate identifier, AGID, is the digest of the concatenated SAIDs of the blocks, in order.
+type Aggregator struct {
+	Diger  *Diger
+	Blocks []types.Map
+}
+
+// NewAggregator blinds each of attributes in a block and computes the aggregate identifier. The SAIDs
+// of the blocks are computed as serialized by kind, that of the credential.
+func NewAggregator(attributes []types.Map, code *types.Code, kind *types.Kind) (*Aggregator, error) {
+	c := &compactor{code: codex.Blake3_256, kind: cesrgo.Kind_JSON, saidify: true}
+	if code != nil {
+		c.code = *code
+	}
+
+	if kind != nil {
+		c.kind = *kind
+	}
+
+	a := &Aggregator{}
+	saids := make([]string, len(attributes))
+
+	for i, attribute := range attributes {
+		noncer, err := NewNoncer(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		nonce, err := noncer.Qb64()
+		if err != nil {
+			return nil, err
+		}
+
+		block := types.NewMap()
+		block.Set("d", "")
+		block.Set("u", string(nonce))
+
+		for _, label := range attribute.Keys() {
+			if label == "d" || label == "u" {
+				return nil, fmt.Errorf("reserved attribute label: %s", label)
+			}
+
+			value, _ := attribute.Get(label)
+			block.Set(label, value)
+		}
+
+		expanded, said, err := c.value(block, "d", false)
+		if err != nil {
+			return nil, err
+		}
+
+		blinded, _ := expanded.(types.Map)
+		a.Blocks = append(a.Blocks, blinded)
+		saids[i], _ = said.(string)
+	}
+
+	var err error
+	if a.Diger, err = NewDiger([]byte(strings.Join(saids, "")), options.WithCode(c.code)); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Disclose returns the aggregate with the blocks at indices disclosed and the rest compacted to their
+// SAIDs. The AGID leads the list.
+func (a *Aggregator) Disclose(indices ...int) (types.List, error) {
+	for _, index := range indices {
+		if index < 0 || index >= len(a.Blocks) {
+			return nil, fmt.Errorf("no attribute block at %d", index)
+		}
+	}
+
+	agid, err := a.Diger.Qb64()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate := types.List{string(agid)}
+	for i, block := range a.Blocks {
+		if slices.Contains(indices, i) {
+			aggregate = append(aggregate, block)
+			continue
+		}
+
+		said, _ := block.Get("d")
+		aggregate = append(aggregate, said)
+	}
+
+	return aggregate, nil
+}
+
+// VerifyAggregate verifies a disclosure of an aggregate against its AGID, returning the AGID and the
+// disclosed blocks. Each disclosed block must hash to its SAID, as serialized by kind.
+func VerifyAggregate(aggregate []any, kind types.Kind) (*Diger, []types.Map, error) {
+	c := &compactor{kind: kind}
+	return c.aggregate(aggregate)
+}
+
+// aggregate verifies a disclosure of an aggregate, returning its AGID and its disclosed blocks
+func (c *compactor) aggregate(aggregate []any) (*Diger, []types.Map, error) {
+	if len(aggregate) == 0 {
+		return nil, nil, fmt.Errorf("empty aggregate")
+	}
+
+	agid, ok := aggregate[0].(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("aggregate identifier is not a string: %T", aggregate[0])
+	}
+
+	diger, err := NewDiger(nil, options.WithQb64(types.Qb64(agid)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	saids := []string{}
+	disclosed := []types.Map{}
+
+	for _, element := range aggregate[1:] {
+		switch v := element.(type) {
+		case string:
+			if _, err := NewSaider(nil, nil, nil, options.WithQb64(types.Qb64(v))); err != nil {
+				return nil, nil, err
+			}
+
+			saids = append(saids, v)
+		case types.Map:
+			if _, ok := v.Get("u"); !ok {
+				return nil, nil, fmt.Errorf("attribute block is not blinded")
+			}
+
+			_, said, err := c.value(v, "d", false)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			str, ok := said.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("attribute block has no said")
+			}
+
+			saids = append(saids, str)
+			disclosed = append(disclosed, v)
+		default:
+			return nil, nil, fmt.Errorf("unexpected aggregate element: %T", element)
+		}
+	}
+
+	ok, err = diger.Verify([]byte(strings.Join(saids, "")))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, fmt.Errorf("aggregate mismatch: %s", agid)
+	}
+
+	return diger, disclosed, nil
+}
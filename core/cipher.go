@@ -0,0 +1,31 @@
+package cesr
+
+import (
+	"fmt"
+
+	"github.com/jasoncolburne/cesrgo/common"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+)
+
+// Cipher is encrypted to an Encrypter and opened by its Decrypter. X25519 codes are sealed boxes: fixed
+// size codes hold the qb64 of a seed or salt, so that keystores may hold secrets encrypted, and variable
+// size codes hold a CESR stream or the qb64 or qb2 of a primitive. HPKE codes hold a message encrypted in
+// the Base mode or, authenticating the sender, the Auth mode.
+type Cipher struct {
+	matter
+}
+
+func NewCipher(opts ...options.MatterOption) (*Cipher, error) {
+	c := &Cipher{}
+
+	if err := NewMatter(c, opts...); err != nil {
+		return nil, err
+	}
+
+	if !common.ValidateCode(c.code, codex.CipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", c.code)
+	}
+
+	return c, nil
+}
@@ -9,12 +9,14 @@ import (
 	"github.com/jasoncolburne/cesrgo/common"
 	codex "github.com/jasoncolburne/cesrgo/core/counter"
 	"github.com/jasoncolburne/cesrgo/core/counter/options"
+	"github.com/jasoncolburne/cesrgo/core/counter/two"
 	"github.com/jasoncolburne/cesrgo/core/types"
 )
 
 type Counter struct {
-	code  types.Code
-	count types.Count
+	code    types.Code
+	count   types.Count
+	version types.Version
 }
 
 func (c *Counter) SetCode(code types.Code) {
@@ -33,6 +35,36 @@ func (c *Counter) GetCount() types.Count {
 	return c.count
 }
 
+func (c *Counter) SetVersion(version types.Version) {
+	c.version = version
+}
+
+// GetVersion returns the genus version of the code table the counter is encoded against
+func (c *Counter) GetVersion() types.Version {
+	return c.version
+}
+
+// GenusVersion decodes the version carried by a KERIACDCGenusVersion counter
+func (c *Counter) GenusVersion() (types.Version, error) {
+	if c.code != two.KERIACDCGenusVersion {
+		return types.Version{}, fmt.Errorf("not a genus version counter: %s", c.code)
+	}
+
+	return types.Version{
+		Major: uint32(c.count) >> 12,
+		Minor: uint32(c.count) & 0xfff,
+	}, nil
+}
+
+// GenusVersionCount encodes a version as the count of a KERIACDCGenusVersion counter
+func GenusVersionCount(version types.Version) (types.Count, error) {
+	if version.Major > 63 || version.Minor > 4095 {
+		return 0, fmt.Errorf("invalid version: %d.%d", version.Major, version.Minor)
+	}
+
+	return types.Count(version.Major<<12 | version.Minor), nil
+}
+
 func (c *Counter) Qb2() (types.Qb2, error) {
 	return cbinfil(c)
 }
@@ -68,7 +100,7 @@ func cinfil(c types.Counter) (types.Qb64, error) {
 	code := c.GetCode()
 	count := c.GetCount()
 
-	szg, ok := codex.Sizes[cesrgo.VERSION.Major][code]
+	szg, ok := codex.Sizes[c.GetVersion().Major][code]
 	if !ok {
 		return types.Qb64(""), fmt.Errorf("unknown code: %s", code)
 	}
@@ -92,7 +124,7 @@ func cinfil(c types.Counter) (types.Qb64, error) {
 
 func cexfil(c types.Counter, qb64 types.Qb64) error {
 	if len(qb64) < 2 {
-		return fmt.Errorf("empty material, need more characters")
+		return common.NewShortageError(2 - len(qb64))
 	}
 
 	first := string(qb64[:2])
@@ -106,18 +138,18 @@ func cexfil(c types.Counter, qb64 types.Qb64) error {
 	}
 
 	if len(qb64) < hs {
-		return fmt.Errorf("need more characters")
+		return common.NewShortageError(hs - len(qb64))
 	}
 
 	hard := qb64[:hs]
 
-	szg, ok := codex.Sizes[cesrgo.VERSION.Major][types.Code(hard)]
+	szg, ok := codex.Sizes[c.GetVersion().Major][types.Code(hard)]
 	if !ok {
 		return fmt.Errorf("unsupported code=%s", hard)
 	}
 
 	if len(qb64) < int(szg.Fs) {
-		return fmt.Errorf("need more characters")
+		return common.NewShortageError(int(szg.Fs) - len(qb64))
 	}
 
 	countStr := string(qb64[hs:szg.Fs])
@@ -136,7 +168,7 @@ func cexfil(c types.Counter, qb64 types.Qb64) error {
 
 func cbexfil(c types.Counter, qb2 types.Qb2) error {
 	if len(qb2) < 2 {
-		return fmt.Errorf("empty material, need more bytes")
+		return common.NewShortageError(2 - len(qb2))
 	}
 
 	first, err := common.NabSextets(qb2, 2)
@@ -156,7 +188,7 @@ func cbexfil(c types.Counter, qb2 types.Qb2) error {
 
 	bhs := int(math.Ceil(float64(hs) * 3 / 4))
 	if len(qb2) < bhs {
-		return fmt.Errorf("need more bytes")
+		return common.NewShortageError(bhs - len(qb2))
 	}
 
 	hard, err := common.CodeB2ToB64(qb2, hs)
@@ -164,14 +196,14 @@ func cbexfil(c types.Counter, qb2 types.Qb2) error {
 		return err
 	}
 
-	szg, ok := codex.Sizes[cesrgo.VERSION.Major][types.Code(hard)]
+	szg, ok := codex.Sizes[c.GetVersion().Major][types.Code(hard)]
 	if !ok {
 		return fmt.Errorf("unsupported code=%s", hard)
 	}
 
 	bcs := int(math.Ceil(float64(szg.Fs) * 3 / 4))
 	if len(qb2) < bcs {
-		return fmt.Errorf("need more bytes")
+		return common.NewShortageError(bcs - len(qb2))
 	}
 
 	both, err := common.CodeB2ToB64(qb2, int(szg.Fs))
@@ -199,7 +231,14 @@ func NewCounter(opts ...options.CounterOption) (*Counter, error) {
 		opt(config)
 	}
 
-	c := &Counter{}
+	c := &Counter{version: cesrgo.VERSION}
+	if config.Version != nil {
+		if _, ok := codex.Sizes[config.Version.Major]; !ok {
+			return c, fmt.Errorf("unsupported counter version: %d.%d", config.Version.Major, config.Version.Minor)
+		}
+
+		c.SetVersion(*config.Version)
+	}
 
 	if config.Code != nil && config.Count != nil {
 		if config.Qb2 != nil || config.Qb64 != nil || config.Qb64b != nil {
@@ -10,6 +10,8 @@ type CounterOptions struct {
 	Qb2   *types.Qb2
 	Qb64  *types.Qb64
 	Qb64b *types.Qb64b
+
+	Version *types.Version
 }
 
 type CounterOption func(options *CounterOptions)
@@ -43,3 +45,10 @@ func WithQb64b(qb64b types.Qb64b) CounterOption {
 		options.Qb64b = &qb64b
 	}
 }
+
+// WithVersion selects the genus version whose code table is used, defaulting to cesrgo.VERSION
+func WithVersion(version types.Version) CounterOption {
+	return func(options *CounterOptions) {
+		options.Version = &version
+	}
+}
@@ -0,0 +1,195 @@
+package cesr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jasoncolburne/cesrgo/common"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"github.com/jasoncolburne/cesrgo/crypto"
+)
+
+// Decrypter is a private X25519 decryption key. It may be converted from the Signer of an Ed25519 key
+// pair, in which case its Encrypter is that converted from the Signer's Verfer.
+type Decrypter struct {
+	matter
+	encrypter *Encrypter
+}
+
+func (d *Decrypter) GetEncrypter() *Encrypter {
+	return d.encrypter
+}
+
+func NewDecrypter(signer *Signer, opts ...options.MatterOption) (*Decrypter, error) {
+	d := &Decrypter{}
+
+	config := &options.MatterOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if signer != nil {
+		if config.Code != nil || config.Raw != nil || config.Qb2 != nil || config.Qb64 != nil || config.Qb64b != nil {
+			return nil, fmt.Errorf("signer cannot be used with code, raw, qb2, qb64, or qb64b")
+		}
+
+		code, raw, err := crypto.ConvertSeed(signer.GetCode(), signer.GetRaw())
+		if err != nil {
+			return nil, err
+		}
+
+		opts = []options.MatterOption{options.WithCode(code), options.WithRaw(raw)}
+	} else if config.Qb2 == nil && config.Qb64 == nil && config.Qb64b == nil {
+		code := codex.X25519_Private
+		if config.Code != nil {
+			code = *config.Code
+		}
+
+		opts = []options.MatterOption{options.WithCode(code)}
+		if config.Raw == nil {
+			raw, err := crypto.GenerateSeed(code)
+			if err != nil {
+				return nil, err
+			}
+
+			opts = append(opts, options.WithRaw(raw))
+		} else {
+			opts = append(opts, options.WithRaw(*config.Raw))
+		}
+	}
+
+	if err := NewMatter(d, opts...); err != nil {
+		return nil, err
+	}
+
+	if d.code != codex.X25519_Private {
+		return nil, fmt.Errorf("unexpected code: %s", d.code)
+	}
+
+	code, raw, err := crypto.DeriveEncryptionKey(d.code, d.raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.encrypter, err = NewEncrypter(nil, options.WithCode(code), options.WithRaw(raw)); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Decrypt opens cipher, a sealed box, returning the plaintext
+func (d *Decrypter) Decrypt(cipher *Cipher) ([]byte, error) {
+	if common.ValidateCode(cipher.GetCode(), codex.HPKEBaseCipherCodex) ||
+		common.ValidateCode(cipher.GetCode(), codex.HPKEAuthCipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	return crypto.Decrypt(d.code, d.raw, cipher.GetRaw())
+}
+
+// DecryptSigner opens a cipher of a seed. Transferability is not held in the seed, so it must be given.
+func (d *Decrypter) DecryptSigner(cipher *Cipher, transferable bool) (*Signer, error) {
+	if cipher.GetCode() != codex.X25519_Cipher_Seed {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	plain, err := d.Decrypt(cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSigner(transferable, options.WithQb64b(plain))
+}
+
+// DecryptSalter opens a cipher of a salt, which stretches at tier
+func (d *Decrypter) DecryptSalter(cipher *Cipher, tier *types.Tier) (*Salter, error) {
+	if cipher.GetCode() != codex.X25519_Cipher_Salt {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	plain, err := d.Decrypt(cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := &UndifferentiatedMatter{}
+	if err := NewMatter(salt, options.WithQb64b(plain)); err != nil {
+		return nil, err
+	}
+
+	return NewSalter(tier, options.WithCode(salt.code), options.WithRaw(salt.raw))
+}
+
+// DecryptStream opens a cipher of a CESR stream, parsing the messages it holds
+func (d *Decrypter) DecryptStream(cipher *Cipher) ([]*Message, error) {
+	if !common.ValidateCode(cipher.GetCode(), codex.StreamCipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	plain, err := d.Decrypt(cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewParser(bytes.NewReader(plain)).ParseAll()
+}
+
+// DecryptQb64 opens a cipher of the qb64 of a primitive
+func (d *Decrypter) DecryptQb64(cipher *Cipher) (*UndifferentiatedMatter, error) {
+	if !common.ValidateCode(cipher.GetCode(), codex.QB64CipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	plain, err := d.Decrypt(cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &UndifferentiatedMatter{}
+	if err := NewMatter(m, options.WithQb64b(plain)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// DecryptQb2 opens a cipher of the qb2 of a primitive
+func (d *Decrypter) DecryptQb2(cipher *Cipher) (*UndifferentiatedMatter, error) {
+	if !common.ValidateCode(cipher.GetCode(), codex.QB2CipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	plain, err := d.Decrypt(cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &UndifferentiatedMatter{}
+	if err := NewMatter(m, options.WithQb2(plain)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// DecryptBase opens a cipher encrypted with HPKE in the Base mode
+func (d *Decrypter) DecryptBase(cipher *Cipher) ([]byte, error) {
+	if !common.ValidateCode(cipher.GetCode(), codex.HPKEBaseCipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	return crypto.DecryptHPKE(d.code, d.raw, nil, cipher.GetRaw())
+}
+
+// DecryptAuth opens a cipher encrypted with HPKE in the Auth mode, failing unless it was sent by the holder
+// of the decryption key of sender
+func (d *Decrypter) DecryptAuth(cipher *Cipher, sender *Encrypter) ([]byte, error) {
+	if !common.ValidateCode(cipher.GetCode(), codex.HPKEAuthCipherCodex) {
+		return nil, fmt.Errorf("unexpected code: %s", cipher.GetCode())
+	}
+
+	return crypto.DecryptHPKE(d.code, d.raw, sender.raw, cipher.GetRaw())
+}
@@ -0,0 +1,60 @@
+package cesr
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// DuplicityKind classifies conflicting events for the same prefix and sequence number
+type DuplicityKind string
+
+const (
+	// a validly signed event that conflicts with the accepted one, evidence of a compromised controller
+	Duplicity_Likely = DuplicityKind("likely")
+	// a rotation that superseded accepted interactions following the last establishment event
+	Duplicity_Recovery = DuplicityKind("recovery")
+)
+
+// Duplicity records two versions of the event at sn. For a recovery, Superseded holds every event the
+// rotation displaced, starting with the one at sn.
+type Duplicity struct {
+	Kind        DuplicityKind
+	Pre         types.Qb64
+	Sn          big.Int
+	First       *SignedEvent
+	Conflicting *SignedEvent
+	Superseded  []*SignedEvent
+}
+
+// Evidence serializes the first seen and conflicting events with their signatures, for forwarding
+// to watchers
+func (d *Duplicity) Evidence() (types.Raw, error) {
+	evidence := types.Raw{}
+	for _, event := range []*SignedEvent{d.First, d.Conflicting} {
+		msg, err := event.Messagize()
+		if err != nil {
+			return nil, err
+		}
+
+		evidence = append(evidence, msg...)
+	}
+
+	return evidence, nil
+}
+
+// DuplicityError reports that an event conflicts with an accepted one and was recorded as duplicitous
+type DuplicityError struct {
+	Duplicity *Duplicity
+}
+
+func (e *DuplicityError) Error() string {
+	return fmt.Sprintf(
+		"duplicitous event for %s at sn %s: %s conflicts with %s",
+		e.Duplicity.Pre,
+		e.Duplicity.Sn.Text(16),
+		e.Duplicity.Conflicting.Serder.Said(),
+		e.Duplicity.First.Serder.Said(),
+	)
+}
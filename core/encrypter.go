@@ -0,0 +1,158 @@
+package cesr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jasoncolburne/cesrgo/common"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"github.com/jasoncolburne/cesrgo/crypto"
+)
+
+// Encrypter is a public X25519 encryption key. It may be converted from the Verfer of an Ed25519 key
+// pair, so that messages to an identifier may be encrypted with its current signing key.
+type Encrypter struct {
+	matter
+}
+
+func NewEncrypter(verfer *Verfer, opts ...options.MatterOption) (*Encrypter, error) {
+	e := &Encrypter{}
+
+	config := &options.MatterOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if verfer != nil {
+		if config.Code != nil || config.Raw != nil || config.Qb2 != nil || config.Qb64 != nil || config.Qb64b != nil {
+			return nil, fmt.Errorf("verfer cannot be used with code, raw, qb2, qb64, or qb64b")
+		}
+
+		code, raw, err := crypto.ConvertPublicKey(verfer.GetCode(), verfer.GetRaw())
+		if err != nil {
+			return nil, err
+		}
+
+		opts = []options.MatterOption{options.WithCode(code), options.WithRaw(raw)}
+	} else if config.Raw != nil && config.Code == nil {
+		opts = append(opts, options.WithCode(codex.X25519))
+	}
+
+	if err := NewMatter(e, opts...); err != nil {
+		return nil, err
+	}
+
+	if e.code != codex.X25519 {
+		return nil, fmt.Errorf("unexpected code: %s", e.code)
+	}
+
+	return e, nil
+}
+
+// VerifySeed reports whether the seed of signer converts to the private key of this encryption key
+func (e *Encrypter) VerifySeed(signer *Signer) (bool, error) {
+	code, raw, err := crypto.ConvertSeed(signer.GetCode(), signer.GetRaw())
+	if err != nil {
+		return false, err
+	}
+
+	_, pub, err := crypto.DeriveEncryptionKey(code, raw)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(pub, e.raw), nil
+}
+
+// the qb64 size of a seed sealed in an X25519_Cipher_Seed
+const cipherSeedQb64Size = 44
+
+// Encrypt seals the qb64 of prim, a 44 character seed or a salt, in a Cipher of fixed size. Larger
+// seeds, such as Ed448, must be sealed with EncryptQb64.
+func (e *Encrypter) Encrypt(prim types.Matter) (*Cipher, error) {
+	qb64b, err := prim.Qb64b()
+	if err != nil {
+		return nil, err
+	}
+
+	var code types.Code
+
+	switch {
+	case common.ValidateCode(prim.GetCode(), codex.SeedCodex):
+		if len(qb64b) != cipherSeedQb64Size {
+			return nil, fmt.Errorf(
+				"seed %s is %d characters, a fixed size cipher seals %d: use EncryptQb64",
+				prim.GetCode(),
+				len(qb64b),
+				cipherSeedQb64Size,
+			)
+		}
+
+		code = codex.X25519_Cipher_Seed
+	case prim.GetCode() == codex.Salt_128:
+		code = codex.X25519_Cipher_Salt
+	default:
+		return nil, fmt.Errorf("unexpected code: %s", prim.GetCode())
+	}
+
+	return e.seal(code, qb64b)
+}
+
+// EncryptStream seals ser, a CESR stream, in a Cipher of variable size
+func (e *Encrypter) EncryptStream(ser []byte) (*Cipher, error) {
+	return e.seal(codex.X25519_Cipher_L0, ser)
+}
+
+// EncryptQb64 seals the qb64 of prim in a Cipher of variable size
+func (e *Encrypter) EncryptQb64(prim types.Matter) (*Cipher, error) {
+	qb64b, err := prim.Qb64b()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.seal(codex.X25519_Cipher_QB64_L0, qb64b)
+}
+
+// EncryptQb2 seals the qb2 of prim in a Cipher of variable size
+func (e *Encrypter) EncryptQb2(prim types.Matter) (*Cipher, error) {
+	qb2, err := prim.Qb2()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.seal(codex.X25519_Cipher_QB2_L0, qb2)
+}
+
+// EncryptBase encrypts plain with HPKE in the Base mode, which does not authenticate the sender
+func (e *Encrypter) EncryptBase(plain []byte) (*Cipher, error) {
+	raw, err := crypto.EncryptHPKE(e.code, e.raw, nil, plain)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCipher(options.WithCode(codex.HPKEBase_Cipher_L0), options.WithRaw(raw))
+}
+
+// EncryptAuth encrypts plain with HPKE in the Auth mode, authenticating the sender by its decryption key.
+// The recipient opens it with the sender's Encrypter, which may be converted from the sender's Verfer.
+func (e *Encrypter) EncryptAuth(plain []byte, sender *Decrypter) (*Cipher, error) {
+	raw, err := crypto.EncryptHPKE(e.code, e.raw, sender.raw, plain)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCipher(options.WithCode(codex.HPKEAuth_Cipher_L0), options.WithRaw(raw))
+}
+
+// seal encrypts plain in a Cipher of code. A variable sized code is resized to the lead and size of the
+// ciphertext.
+func (e *Encrypter) seal(code types.Code, plain []byte) (*Cipher, error) {
+	raw, err := crypto.Encrypt(e.code, e.raw, plain)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCipher(options.WithCode(code), options.WithRaw(raw))
+}
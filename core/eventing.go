@@ -0,0 +1,478 @@
+package cesr
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	mopts "github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// Incept builds an inception event for the signing keys of verfers, committing to the next keys digested
+// by digers. The prefix is basic when a single key is given and no digest code is selected, and
+// self-addressing (Blake3_256 by default) otherwise. A delegator makes the event a delegated inception, which must be
+// self-addressing.
+func Incept(verfers []*Verfer, digers []*Diger, opts ...eopts.EventOption) (*SerderKERI, error) {
+	config := &eopts.EventOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	keys, err := qb64sOf(verfers)
+	if err != nil {
+		return nil, err
+	}
+
+	ndigs, err := qb64sOf(digers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+
+	sith, err := eventSith(config.Sith, len(keys), 1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sith: %w", err)
+	}
+
+	nsith, err := eventSith(config.NextSith, len(ndigs), 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next sith: %w", err)
+	}
+
+	if err := validateWits(config.Wits); err != nil {
+		return nil, err
+	}
+
+	toad, err := eventToad(config.Toad, len(config.Wits))
+	if err != nil {
+		return nil, err
+	}
+
+	ilk := cesrgo.Ilk_ICP
+	code := config.Code
+	if config.Delegator != nil {
+		ilk = cesrgo.Ilk_DIP
+	}
+
+	if code == nil && (config.Delegator != nil || len(keys) > 1) {
+		blake3 := codex.Blake3_256
+		code = &blake3
+	}
+
+	digestive := code != nil && slices.Contains(codex.DigCodex, *code)
+	if !digestive {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("basic prefix requires a single key, got %d", len(keys))
+		}
+
+		if config.Delegator != nil {
+			return nil, fmt.Errorf("delegated prefix must be self-addressing")
+		}
+
+		keyCode := verfers[0].GetCode()
+		if !slices.Contains(codex.PreNonDigCodex, keyCode) || (code != nil && *code != keyCode) {
+			return nil, fmt.Errorf("invalid basic prefix code: %s", keyCode)
+		}
+
+		if slices.Contains(codex.NonTransCodex, keyCode) &&
+			(len(ndigs) > 0 || len(config.Wits) > 0 || len(config.Data) > 0) {
+			return nil, fmt.Errorf("non-transferable prefix cannot commit to next keys, witnesses or data")
+		}
+	}
+
+	ked := types.NewMap()
+	if err := eventVersion(&ked, config.Version, config.Kind); err != nil {
+		return nil, err
+	}
+
+	ked.Set("t", string(ilk))
+	ked.Set("d", "")
+	ked.Set("i", string(keys[0]))
+	ked.Set("s", "0")
+	ked.Set("kt", sith)
+	ked.Set("k", anysOf(keys))
+	ked.Set("nt", nsith)
+	ked.Set("n", anysOf(ndigs))
+	ked.Set("bt", fmt.Sprintf("%x", toad))
+	ked.Set("b", anysOf(config.Wits))
+	ked.Set("c", anysOf(config.Traits))
+	ked.Set("a", anysOf(config.Data))
+
+	if config.Delegator != nil {
+		ked.Set("di", string(*config.Delegator))
+	}
+
+	labels := []string{"d"}
+	if digestive {
+		labels = append(labels, "i")
+	} else {
+		code = nil
+	}
+
+	return saidifyEvent(ked, code, config.Kind, labels)
+}
+
+// Rotate builds the rotation following state to the keys of verfers, which must satisfy the prior next
+// threshold, committing to the next keys digested by digers. Witnesses are rotated by the cuts and
+// adds options. A delegated identifier produces a delegated rotation. Configuration traits can only be
+// set at inception, so the traits option is rejected, and a v2 rotation carries an empty c.
+func Rotate(state *KeyState, verfers []*Verfer, digers []*Diger, opts ...eopts.EventOption) (*SerderKERI, error) {
+	config := &eopts.EventOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if len(config.Traits) > 0 {
+		return nil, fmt.Errorf("rotation may not change configuration traits: %v", config.Traits)
+	}
+
+	keys, err := qb64sOf(verfers)
+	if err != nil {
+		return nil, err
+	}
+
+	ndigs, err := qb64sOf(digers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+
+	sith, err := eventSith(config.Sith, len(keys), 1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sith: %w", err)
+	}
+
+	nsith, err := eventSith(config.NextSith, len(ndigs), 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next sith: %w", err)
+	}
+
+	// the rotation must be signed by keys exposing the prior next keys, so these must at least
+	if err := exposes(state.NextDigers, state.NextTholder, verfers); err != nil {
+		return nil, err
+	}
+
+	wits, err := rotateWits(state.Wits, config.Cuts, config.Adds)
+	if err != nil {
+		return nil, err
+	}
+
+	toad, err := eventToad(config.Toad, len(wits))
+	if err != nil {
+		return nil, err
+	}
+
+	ilk := cesrgo.Ilk_ROT
+	if state.Delegated() {
+		ilk = cesrgo.Ilk_DRT
+	}
+
+	ked, pvrsn, kind, err := subsequentEvent(state, ilk, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ked.Set("kt", sith)
+	ked.Set("k", anysOf(keys))
+	ked.Set("nt", nsith)
+	ked.Set("n", anysOf(ndigs))
+	ked.Set("bt", fmt.Sprintf("%x", toad))
+	ked.Set("br", anysOf(config.Cuts))
+	ked.Set("ba", anysOf(config.Adds))
+
+	if slices.Contains(KERIFields[pvrsn.Major][ilk], "c") {
+		ked.Set("c", []any{})
+	}
+
+	ked.Set("a", anysOf(config.Data))
+
+	return appendEvent(state, ked, config.Code, kind)
+}
+
+// Interact builds the interaction following state, anchoring the data option
+func Interact(state *KeyState, opts ...eopts.EventOption) (*SerderKERI, error) {
+	config := &eopts.EventOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	ked, _, kind, err := subsequentEvent(state, cesrgo.Ilk_IXN, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ked.Set("a", anysOf(config.Data))
+
+	return appendEvent(state, ked, config.Code, kind)
+}
+
+// subsequentEvent starts the ked of the event following state, defaulting the version and kind to
+// those of state
+func subsequentEvent(state *KeyState, ilk types.Ilk, config *eopts.EventOptions) (types.Map, types.Version, types.Kind, error) {
+	pvrsn := state.Version
+	if config.Version != nil {
+		pvrsn = *config.Version
+	}
+
+	kind := state.Kind
+	if config.Kind != nil {
+		kind = *config.Kind
+	}
+
+	sn := new(big.Int).Add(&state.Sn, big.NewInt(1))
+	seqner, err := NewSeqner(sn, nil)
+	if err != nil {
+		return types.Map{}, types.Version{}, "", err
+	}
+
+	next := seqner.Sn()
+
+	ked := types.NewMap()
+	if err := eventVersion(&ked, &pvrsn, &kind); err != nil {
+		return types.Map{}, types.Version{}, "", err
+	}
+
+	ked.Set("t", string(ilk))
+	ked.Set("d", "")
+	ked.Set("i", string(state.Pre))
+	ked.Set("s", next.Text(16))
+	ked.Set("p", string(state.Dig))
+
+	return ked, pvrsn, kind, nil
+}
+
+// appendEvent saidifies ked and checks that it validly follows state
+func appendEvent(state *KeyState, ked types.Map, code *types.Code, kind types.Kind) (*SerderKERI, error) {
+	serder, err := saidifyEvent(ked, code, &kind, []string{"d"})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := state.Apply(serder); err != nil {
+		return nil, err
+	}
+
+	return serder, nil
+}
+
+// Receipt builds the receipt of a key event, to be attached with the receipting signatures. The version
+// and kind default to those of the receipted event.
+func Receipt(serder *SerderKERI, opts ...eopts.EventOption) (*SerderKERI, error) {
+	config := &eopts.EventOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	pvrsn := serder.GetVersion()
+	if config.Version != nil {
+		pvrsn = *config.Version
+	}
+
+	kind := serder.GetKind()
+	if config.Kind != nil {
+		kind = *config.Kind
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return nil, err
+	}
+
+	ked := types.NewMap()
+	if err := eventVersion(&ked, &pvrsn, &kind); err != nil {
+		return nil, err
+	}
+
+	ked.Set("t", string(cesrgo.Ilk_RCT))
+	ked.Set("d", serder.Said())
+	ked.Set("i", serder.Pre())
+	ked.Set("s", sn.Text(16))
+
+	return NewSerderKERI(nil, nil, &ked, &kind, false)
+}
+
+// EventSeal returns the seal of an event, for anchoring it in another event's data
+func EventSeal(serder *SerderKERI) (types.Map, error) {
+	sn, err := serder.Sn()
+	if err != nil {
+		return types.Map{}, err
+	}
+
+	seal := types.NewMap()
+	seal.Set("i", serder.Pre())
+	seal.Set("s", sn.Text(16))
+	seal.Set("d", serder.Said())
+
+	return seal, nil
+}
+
+// saidifyEvent sizes ked, derives its SAID with every one of labels dummied and places the SAID in them
+func saidifyEvent(ked types.Map, code *types.Code, kind *types.Kind, labels []string) (*SerderKERI, error) {
+	if code == nil {
+		blake3 := codex.Blake3_256
+		code = &blake3
+	}
+
+	szg, ok := codex.Sizes[*code]
+	if !ok || szg.Fs == nil {
+		return nil, fmt.Errorf("unknown code: %s", *code)
+	}
+
+	for _, label := range labels {
+		ked.Set(label, strings.Repeat("#", int(*szg.Fs)))
+	}
+
+	// sizes the version string with the dummies in place
+	sized, err := NewSerderKERI(code, nil, &ked, kind, false)
+	if err != nil {
+		return nil, err
+	}
+
+	sad := sized.GetKed()
+	sadKind := sized.GetKind()
+
+	raw, _, err := derive(&sad, code, &sadKind, labels, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	saider, err := NewSaider(nil, nil, nil, mopts.WithCode(*code), mopts.WithRaw(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	said, err := saider.Qb64()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, label := range labels {
+		sad.Set(label, string(said))
+	}
+
+	return NewSerderKERI(code, nil, &sad, &sadKind, false)
+}
+
+// eventVersion sets the version string placeholder for the protocol version, defaulting to cesrgo.VERSION
+func eventVersion(ked *types.Map, pvrsn *types.Version, kind *types.Kind) error {
+	proto := cesrgo.Proto_KERI
+
+	v, err := common.Versify(&proto, pvrsn, kind, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	ked.Set("v", v)
+
+	return nil
+}
+
+// eventSith validates sith against count, defaulting to a simple majority no smaller than minimum
+func eventSith(sith any, count, minimum int) (any, error) {
+	if sith == nil {
+		sith = max(minimum, (count+1)/2)
+	}
+
+	tholder, err := NewTholder(nil, nil, sith)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tholder.Weighted() {
+		thold, ok := tholder.Thold().(int)
+		if !ok || thold < minimum {
+			return nil, fmt.Errorf("threshold below %d", minimum)
+		}
+	}
+
+	//nolint:gosec
+	if int(tholder.Size()) > count {
+		return nil, fmt.Errorf("threshold size %d exceeds %d", tholder.Size(), count)
+	}
+
+	return tholder.Sith()
+}
+
+// eventToad validates the witness threshold, defaulting to a sufficient majority
+func eventToad(toad *int, count int) (int, error) {
+	if toad == nil {
+		return ample(count), nil
+	}
+
+	if count == 0 {
+		if *toad != 0 {
+			return 0, fmt.Errorf("invalid toad %d for no witnesses", *toad)
+		}
+	} else if *toad < 1 || *toad > count {
+		return 0, fmt.Errorf("invalid toad %d for %d witnesses", *toad, count)
+	}
+
+	return *toad, nil
+}
+
+// ample is the smallest witness threshold that guarantees agreement when up to a third of count
+// witnesses are faulty
+func ample(count int) int {
+	if count <= 0 {
+		return 0
+	}
+
+	f1 := max(1, (count-1)/3)
+	f2 := max(1, (count-1+2)/3)
+
+	return min(count, (count+f1+2)/2, (count+f2+2)/2)
+}
+
+func validateWits(wits []types.Qb64) error {
+	for i, wit := range wits {
+		if slices.Contains(wits[:i], wit) {
+			return fmt.Errorf("duplicate witness: %s", wit)
+		}
+
+		if _, err := NewPrefixer(mopts.WithQb64(wit)); err != nil {
+			return fmt.Errorf("invalid witness %s: %w", wit, err)
+		}
+	}
+
+	return nil
+}
+
+func qb64sOf[T types.Matter](matters []T) ([]types.Qb64, error) {
+	qb64s := make([]types.Qb64, len(matters))
+	for i, m := range matters {
+		qb64, err := m.Qb64()
+		if err != nil {
+			return nil, err
+		}
+
+		qb64s[i] = qb64
+	}
+
+	return qb64s, nil
+}
+
+func anysOf[T ~string | types.Map](values []T) []any {
+	anys := make([]any, len(values))
+	for i, value := range values {
+		switch v := any(value).(type) {
+		case types.Map:
+			anys[i] = v
+		default:
+			anys[i] = fmt.Sprint(v)
+		}
+	}
+
+	return anys
+}
@@ -0,0 +1,104 @@
+package options
+
+import (
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+type EventOptions struct {
+	Sith     any
+	NextSith any
+	Toad     *int
+
+	Wits []types.Qb64
+	Cuts []types.Qb64
+	Adds []types.Qb64
+
+	Traits []types.Trait
+	Data   []types.Map
+
+	Code      *types.Code
+	Delegator *types.Qb64
+
+	Kind    *types.Kind
+	Version *types.Version
+}
+
+type EventOption func(options *EventOptions)
+
+// WithSith sets the signing threshold, defaulting to a simple majority of the keys
+func WithSith(sith any) EventOption {
+	return func(options *EventOptions) {
+		options.Sith = sith
+	}
+}
+
+// WithNextSith sets the threshold of the next key set, defaulting to a simple majority of the digests
+func WithNextSith(sith any) EventOption {
+	return func(options *EventOptions) {
+		options.NextSith = sith
+	}
+}
+
+// WithToad sets the witness threshold, defaulting to a sufficient majority of the witnesses
+func WithToad(toad int) EventOption {
+	return func(options *EventOptions) {
+		options.Toad = &toad
+	}
+}
+
+func WithWits(wits []types.Qb64) EventOption {
+	return func(options *EventOptions) {
+		options.Wits = wits
+	}
+}
+
+func WithCuts(cuts []types.Qb64) EventOption {
+	return func(options *EventOptions) {
+		options.Cuts = cuts
+	}
+}
+
+func WithAdds(adds []types.Qb64) EventOption {
+	return func(options *EventOptions) {
+		options.Adds = adds
+	}
+}
+
+func WithTraits(traits []types.Trait) EventOption {
+	return func(options *EventOptions) {
+		options.Traits = traits
+	}
+}
+
+func WithData(data []types.Map) EventOption {
+	return func(options *EventOptions) {
+		options.Data = data
+	}
+}
+
+// WithCode selects the derivation code of the prefix
+func WithCode(code types.Code) EventOption {
+	return func(options *EventOptions) {
+		options.Code = &code
+	}
+}
+
+// WithDelegator makes the event a delegated one, anchored by delegator
+func WithDelegator(delegator types.Qb64) EventOption {
+	return func(options *EventOptions) {
+		options.Delegator = &delegator
+	}
+}
+
+func WithKind(kind types.Kind) EventOption {
+	return func(options *EventOptions) {
+		options.Kind = &kind
+	}
+}
+
+// WithVersion selects the protocol version of the event, defaulting to cesrgo.VERSION
+func WithVersion(version types.Version) EventOption {
+	return func(options *EventOptions) {
+		options.Version = &version
+	}
+}
@@ -72,7 +72,7 @@ func (i *indexer) Qb64b() (types.Qb64b, error) {
 
 func ibexfil(i types.Indexer, qb2 types.Qb2) error {
 	if len(qb2) == 0 {
-		return fmt.Errorf("qb2 is empty")
+		return common.NewShortageError(1)
 	}
 
 	first, err := common.NabSextets(qb2, 1)
@@ -87,7 +87,7 @@ func ibexfil(i types.Indexer, qb2 types.Qb2) error {
 
 	bhs := int(math.Ceil(float64(hs) * 3 / 4))
 	if len(qb2) < bhs {
-		return fmt.Errorf("insufficient material for hard part of code: qb2 size = %d, bhs = %d", len(qb2), bhs)
+		return common.NewShortageError(bhs - len(qb2))
 	}
 
 	hard, err := common.CodeB2ToB64(qb2, hs)
@@ -104,7 +104,7 @@ func ibexfil(i types.Indexer, qb2 types.Qb2) error {
 	bcs := ((cs + 1) * 3) / 4
 
 	if len(qb2) < int(bcs) {
-		return fmt.Errorf("insufficient material for code: qb2 size = %d, bcs = %d", len(qb2), bcs)
+		return common.NewShortageError(int(bcs) - len(qb2))
 	}
 
 	both, err := common.CodeB2ToB64(qb2, int(cs))
@@ -163,7 +163,7 @@ func ibexfil(i types.Indexer, qb2 types.Qb2) error {
 
 	bfs := int(math.Ceil(float64(fs)*3) / 4)
 	if len(qb2) < bfs {
-		return fmt.Errorf("insufficient material: qb2 size = %d, bfs = %d", len(qb2), bfs)
+		return common.NewShortageError(bfs - len(qb2))
 	}
 
 	trim := qb2[:bfs]
@@ -192,7 +192,7 @@ func ibexfil(i types.Indexer, qb2 types.Qb2) error {
 		}
 	}
 
-	raw := trim[bcs+szg.Ls:]
+	raw := slices.Clone(trim[bcs+szg.Ls:])
 	if len(raw) != len(trim)-int(bcs)-int(szg.Ls) {
 		// unreachable. rust prevents this by the definition of `raw` above.
 		return fmt.Errorf("improperly qualified material: qb2 = %v", qb2)
@@ -376,7 +376,7 @@ func iinfil(i types.Indexer) (types.Qb64, error) {
 
 func iexfil(i types.Indexer, qb64 types.Qb64) error {
 	if len(qb64) == 0 {
-		return fmt.Errorf("qb64 is empty")
+		return common.NewShortageError(1)
 	}
 
 	first := qb64[0]
@@ -385,6 +385,10 @@ func iexfil(i types.Indexer, qb64 types.Qb64) error {
 		return fmt.Errorf("unknown hard: %x", first)
 	}
 
+	if len(qb64) < hs {
+		return common.NewShortageError(hs - len(qb64))
+	}
+
 	hard := qb64[:hs]
 	szg, ok := codex.Sizes[types.Code(hard)]
 	if !ok {
@@ -395,7 +399,7 @@ func iexfil(i types.Indexer, qb64 types.Qb64) error {
 	ms := szg.Ss - szg.Os
 
 	if len(qb64) < int(cs) {
-		return fmt.Errorf("insufficient material for code: qb64 size = %d, cs = %d", len(qb64), cs)
+		return common.NewShortageError(int(cs) - len(qb64))
 	}
 
 	indexB64 := qb64[hs : hs+int(ms)]
@@ -453,7 +457,7 @@ func iexfil(i types.Indexer, qb64 types.Qb64) error {
 	}
 
 	if len(qb64) < int(fs) {
-		return fmt.Errorf("insufficient material: qb64 size = %d, fs = %d", len(qb64), fs)
+		return common.NewShortageError(int(fs) - len(qb64))
 	}
 
 	qb64 = qb64[:fs]
@@ -0,0 +1,334 @@
+package cesr
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/core/counter/one"
+	"github.com/jasoncolburne/cesrgo/core/counter/options"
+	"github.com/jasoncolburne/cesrgo/core/counter/two"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// SignedEvent is a key event with its controller and witness signatures and the receipts collected for
+// it. Source locates the delegator's event that anchors a delegated event. Cigars are receipts by
+// non-transferable identifiers, each carrying its verfer, and Vrcs are receipts by transferable
+// validators. FirstSeen holds the first seen ordinal and time of an accepted event, or those replayed
+// with it.
+type SignedEvent struct {
+	Serder    *SerderKERI
+	Sigers    []*Siger
+	Wigers    []*Siger
+	Source    *SealSourceCouple
+	Cigars    []*Cigar
+	Vrcs      []TransIdxSigGroup
+	FirstSeen *FirstSeenReplayCouple
+
+	// the prefix named by a source seal triple, which must be the delegator
+	sourcePre types.Qb64
+}
+
+// Messagize serializes the event with its attachments, as it would appear in a stream
+func (e *SignedEvent) Messagize() (types.Raw, error) {
+	version := e.Serder.GetVersion()
+
+	codes, ok := map[uint32][6]types.Code{
+		cesrgo.VERSION_1_0.Major: {
+			one.ControllerIdxSigs,
+			one.WitnessIdxSigs,
+			one.SealSourceCouples,
+			one.NonTransReceiptCouples,
+			one.TransIdxSigGroups,
+			one.FirstSeenReplayCouples,
+		},
+		cesrgo.VERSION_2_0.Major: {
+			two.ControllerIdxSigs,
+			two.WitnessIdxSigs,
+			two.SealSourceCouples,
+			two.NonTransReceiptCouples,
+			two.TransIdxSigGroups,
+			two.FirstSeenReplayCouples,
+		},
+	}[version.Major]
+	if !ok {
+		return nil, fmt.Errorf("unsupported version: %d.%d", version.Major, version.Minor)
+	}
+
+	groups := [6][][]byte{}
+
+	for i, sigers := range [][]*Siger{e.Sigers, e.Wigers} {
+		for _, siger := range sigers {
+			qb64b, err := siger.Qb64b()
+			if err != nil {
+				return nil, err
+			}
+
+			groups[i] = append(groups[i], qb64b)
+		}
+	}
+
+	if e.Source != nil {
+		seqner, err := e.Source.Seqner.Qb64b()
+		if err != nil {
+			return nil, err
+		}
+
+		saider, err := e.Source.Saider.Qb64b()
+		if err != nil {
+			return nil, err
+		}
+
+		groups[2] = append(groups[2], append(seqner, saider...))
+	}
+
+	for _, cigar := range e.Cigars {
+		verfer, err := cigar.GetVerfer().Qb64b()
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := cigar.Qb64b()
+		if err != nil {
+			return nil, err
+		}
+
+		groups[3] = append(groups[3], append(verfer, sig...))
+	}
+
+	for _, vrc := range e.Vrcs {
+		item := []byte{}
+		for _, m := range []types.Matter{vrc.Prefixer, vrc.Seqner, vrc.Saider} {
+			qb64b, err := m.Qb64b()
+			if err != nil {
+				return nil, err
+			}
+
+			item = append(item, qb64b...)
+		}
+
+		sigs := [][]byte{}
+		for _, siger := range vrc.Sigers {
+			qb64b, err := siger.Qb64b()
+			if err != nil {
+				return nil, err
+			}
+
+			sigs = append(sigs, qb64b)
+		}
+
+		group, err := attachGroup(codes[0], sigs, version)
+		if err != nil {
+			return nil, err
+		}
+
+		groups[4] = append(groups[4], append(item, group...))
+	}
+
+	if e.FirstSeen != nil {
+		fn, err := e.FirstSeen.Seqner.Qb64b()
+		if err != nil {
+			return nil, err
+		}
+
+		dts, err := e.FirstSeen.Dater.Qb64b()
+		if err != nil {
+			return nil, err
+		}
+
+		groups[5] = append(groups[5], append(fn, dts...))
+	}
+
+	msg := slices.Clone(e.Serder.GetRaw())
+	for i, items := range groups {
+		if len(items) == 0 {
+			continue
+		}
+
+		group, err := attachGroup(codes[i], items, version)
+		if err != nil {
+			return nil, err
+		}
+
+		msg = append(msg, group...)
+	}
+
+	return msg, nil
+}
+
+// attachGroup encodes items as a counted attachment group. v1 counts items, v2 counts quadlets.
+func attachGroup(code types.Code, items [][]byte, version types.Version) ([]byte, error) {
+	body := slices.Concat(items...)
+
+	count := len(items)
+	if version.Major >= cesrgo.VERSION_2_0.Major {
+		count = len(body) / 4
+	}
+
+	counter, err := NewCounter(
+		options.WithCode(code),
+		options.WithCount(types.Count(count)),
+		options.WithVersion(version),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	qb64b, err := counter.Qb64b()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(qb64b, body...), nil
+}
+
+// Kever verifies the key event log of a single identifier, event by event, and holds its key state
+type Kever struct {
+	// accepted events and the state each produced, indexed by sequence number
+	kel    []*SignedEvent
+	states []*KeyState
+	// every accepted event in the order first seen, indexed by first seen ordinal. Events superseded
+	// by a recovery remain.
+	fel []*SignedEvent
+}
+
+// NewKever accepts a signed inception event, establishing the identifier's key state
+func NewKever(serder *SerderKERI, sigers []*Siger) (*Kever, error) {
+	state, err := NewKeyState(serder)
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := state.verify(serder, sigers)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Kever{}
+	if err := k.accept(&SignedEvent{Serder: serder, Sigers: verified}, state); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// State returns the current key state
+func (k *Kever) State() *KeyState {
+	return k.states[len(k.states)-1]
+}
+
+// Serder returns the latest accepted event
+func (k *Kever) Serder() *SerderKERI {
+	return k.kel[len(k.kel)-1].Serder
+}
+
+func (k *Kever) Pre() types.Qb64 {
+	return k.State().Pre
+}
+
+// Event returns the accepted event at sn
+func (k *Kever) Event(sn uint64) (*SignedEvent, bool) {
+	if sn >= uint64(len(k.kel)) {
+		return nil, false
+	}
+
+	return k.kel[sn], true
+}
+
+// Update accepts the signed event that follows the current state. Establishment events are signed by
+// the keys they introduce, interactions by the current keys. The state is unchanged on error.
+func (k *Kever) Update(serder *SerderKERI, sigers []*Siger) error {
+	state, err := k.State().Apply(serder)
+	if err != nil {
+		return err
+	}
+
+	verified, err := state.verify(serder, sigers)
+	if err != nil {
+		return err
+	}
+
+	return k.accept(&SignedEvent{Serder: serder, Sigers: verified}, state)
+}
+
+// FirstSeen returns the event first seen with ordinal fn
+func (k *Kever) FirstSeen(fn uint64) (*SignedEvent, bool) {
+	if fn >= uint64(len(k.fel)) {
+		return nil, false
+	}
+
+	return k.fel[fn], true
+}
+
+// Replay serializes every accepted event in first seen order, each with its attachments and its first
+// seen ordinal and time, so another node can clone the log with Kevery.CloneStream
+func (k *Kever) Replay() (types.Raw, error) {
+	replay := types.Raw{}
+	for _, event := range k.fel {
+		msg, err := event.Messagize()
+		if err != nil {
+			return nil, err
+		}
+
+		replay = append(replay, msg...)
+	}
+
+	return replay, nil
+}
+
+// accept appends an event, truncating any events it supersedes, and logs it as first seen now. An event
+// cloned from a trusted replay keeps its first seen time but takes its ordinal from this log.
+func (k *Kever) accept(event *SignedEvent, state *KeyState) error {
+	fn, err := NewSeqner(new(big.Int).SetUint64(uint64(len(k.fel))), nil)
+	if err != nil {
+		return err
+	}
+
+	var dater *Dater
+	if event.FirstSeen != nil {
+		dater = event.FirstSeen.Dater
+	} else if dater, err = NewDater(nil); err != nil {
+		return err
+	}
+
+	event.FirstSeen = &FirstSeenReplayCouple{Seqner: fn, Dater: dater}
+
+	sn := state.Sn.Uint64()
+
+	k.kel = append(k.kel[:sn], event)
+	k.states = append(k.states[:sn], state)
+	k.fel = append(k.fel, event)
+
+	return nil
+}
+
+// verifySigers verifies sigers against the keys of verfers they index, returning those that verify. The
+// verified indices must satisfy tholder.
+func verifySigers(serder *SerderKERI, verfers []*Verfer, tholder *Tholder, sigers []*Siger) ([]*Siger, error) {
+	verified := []*Siger{}
+	indices := []types.Index{}
+
+	for _, siger := range sigers {
+		index := siger.GetIndex()
+		if int(index) >= len(verfers) || slices.Contains(indices, index) {
+			continue
+		}
+
+		verfer := verfers[index]
+		ok, err := verfer.Verify(siger.GetRaw(), serder.GetRaw())
+		if err != nil || !ok {
+			continue
+		}
+
+		siger.verfer = verfer
+		verified = append(verified, siger)
+		indices = append(indices, index)
+	}
+
+	if !tholder.Satisfy(indices) {
+		return verified, fmt.Errorf("signature threshold not satisfied: %d of %d verified", len(indices), len(sigers))
+	}
+
+	return verified, nil
+}
@@ -0,0 +1,835 @@
+package cesr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// Escrow names the reason an event is held for later processing
+type Escrow string
+
+const (
+	Escrow_OutOfOrder         = Escrow("ooo")
+	Escrow_PartiallySigned    = Escrow("pse")
+	Escrow_PartiallyWitnessed = Escrow("pwe")
+	Escrow_MissingDelegator   = Escrow("lde")
+	Escrow_PartiallyDelegated = Escrow("pde")
+	Escrow_UnverifiedReceipt  = Escrow("ure")
+)
+
+// escrows are retried in this order, since each may release events held by the next
+var ESCROWS = []Escrow{
+	Escrow_UnverifiedReceipt,
+	Escrow_PartiallySigned,
+	Escrow_PartiallyWitnessed,
+	Escrow_MissingDelegator,
+	Escrow_PartiallyDelegated,
+	Escrow_OutOfOrder,
+}
+
+// EscrowError reports that an event could not yet be accepted and was escrowed
+type EscrowError struct {
+	Escrow Escrow
+	Err    error
+}
+
+func (e *EscrowError) Error() string {
+	return fmt.Sprintf("escrowed (%s): %v", e.Escrow, e.Err)
+}
+
+func (e *EscrowError) Unwrap() error {
+	return e.Err
+}
+
+func escrow(escrow Escrow, format string, args ...any) *EscrowError {
+	return &EscrowError{Escrow: escrow, Err: fmt.Errorf(format, args...)}
+}
+
+// Kevery processes key events for any number of identifiers, routing each to the Kever of its prefix.
+// Events that cannot yet be accepted are escrowed and retried whenever another event is accepted.
+type Kevery struct {
+	kevers      map[types.Qb64]*Kever
+	escrows     map[Escrow][]*SignedEvent
+	duplicities map[types.Qb64][]*Duplicity
+}
+
+func NewKevery() *Kevery {
+	return &Kevery{
+		kevers:      map[types.Qb64]*Kever{},
+		escrows:     map[Escrow][]*SignedEvent{},
+		duplicities: map[types.Qb64][]*Duplicity{},
+	}
+}
+
+// Kever returns the Kever of pre, if its inception has been accepted
+func (k *Kevery) Kever(pre types.Qb64) (*Kever, bool) {
+	kever, ok := k.kevers[pre]
+	return kever, ok
+}
+
+// Escrowed lists the events held in escrow
+func (k *Kevery) Escrowed(escrow Escrow) []*SerderKERI {
+	serders := []*SerderKERI{}
+	for _, event := range k.escrows[escrow] {
+		serders = append(serders, event.Serder)
+	}
+
+	return serders
+}
+
+// ProcessStream parses and processes every message read from reader. Escrowed events are not errors;
+// other failures are collected and processing continues with the next message. First seen times
+// replayed with events are ignored, since any peer could backdate them, and each event is stamped with
+// the time this node first sees it.
+func (k *Kevery) ProcessStream(reader io.Reader) error {
+	return k.processStream(reader, false)
+}
+
+// CloneStream processes a replay from a trusted source, as produced by Kever.Replay, like
+// ProcessStream, but each event keeps the first seen time replayed with it
+func (k *Kevery) CloneStream(reader io.Reader) error {
+	return k.processStream(reader, true)
+}
+
+func (k *Kevery) processStream(reader io.Reader, clone bool) error {
+	parser := NewParser(reader)
+
+	var errs []error
+	for {
+		msg, err := parser.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		var escrowed *EscrowError
+		if err := k.processMessage(msg, clone); err != nil && !errors.As(err, &escrowed) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Process processes a parsed message, returning an *EscrowError if its event was escrowed. A replayed
+// first seen time is ignored, as in ProcessStream.
+func (k *Kevery) Process(msg *Message) error {
+	return k.processMessage(msg, false)
+}
+
+// processMessage processes a parsed message, keeping its replayed first seen time when cloning
+func (k *Kevery) processMessage(msg *Message, clone bool) error {
+	if msg.Proto != cesrgo.Proto_KERI {
+		return fmt.Errorf("unexpected protocol: %s", msg.Proto)
+	}
+
+	raw := msg.Raw
+	serder, err := NewSerderKERI(nil, &raw, nil, nil, true)
+	if err != nil {
+		return err
+	}
+
+	event := &SignedEvent{
+		Serder: serder,
+		Sigers: msg.ControllerIdxSigs,
+		Wigers: msg.WitnessIdxSigs,
+		Vrcs:   slices.Clone(msg.TransIdxSigGroups),
+	}
+
+	for _, couple := range msg.NonTransReceiptCouples {
+		event.Cigars = append(event.Cigars, couple.Cigar)
+	}
+
+	for _, quadruple := range msg.TransReceiptQuadruples {
+		event.Vrcs = append(event.Vrcs, TransIdxSigGroup{
+			Prefixer: quadruple.Prefixer,
+			Seqner:   quadruple.Seqner,
+			Saider:   quadruple.Saider,
+			Sigers:   []*Siger{quadruple.Siger},
+		})
+	}
+
+	if len(msg.SealSourceCouples) > 0 {
+		event.Source = &msg.SealSourceCouples[0]
+	} else if len(msg.SealSourceTriples) > 0 {
+		triple := msg.SealSourceTriples[0]
+
+		pre, err := triple.Prefixer.Qb64()
+		if err != nil {
+			return err
+		}
+
+		event.Source = &SealSourceCouple{Seqner: triple.Seqner, Saider: triple.Saider}
+		event.sourcePre = pre
+	}
+
+	// a cloned event keeps the time it was first seen by the node replaying it
+	if clone && len(msg.FirstSeenReplayCouples) > 0 {
+		event.FirstSeen = &msg.FirstSeenReplayCouples[0]
+	}
+
+	return k.ProcessSignedEvent(event)
+}
+
+// ProcessEvent processes a key event with its controller signatures and witness signatures
+func (k *Kevery) ProcessEvent(serder *SerderKERI, sigers, wigers []*Siger) error {
+	return k.ProcessSignedEvent(&SignedEvent{Serder: serder, Sigers: sigers, Wigers: wigers})
+}
+
+// ProcessSignedEvent processes a key event with its attachments, or a receipt with its receipting
+// signatures. Receipts attached to a key event, as in a replay, are processed as a receipt of it.
+func (k *Kevery) ProcessSignedEvent(event *SignedEvent) error {
+	if event.Serder.Ilk() == cesrgo.Ilk_RCT || (len(event.Cigars) == 0 && len(event.Vrcs) == 0) {
+		return k.handle(event)
+	}
+
+	rct, err := Receipt(event.Serder)
+	if err != nil {
+		return err
+	}
+
+	receipt := &SignedEvent{Serder: rct, Cigars: event.Cigars, Vrcs: event.Vrcs}
+
+	held := *event
+	held.Cigars, held.Vrcs = nil, nil
+
+	var escrowed *EscrowError
+
+	err = k.handle(&held)
+	if err != nil && !errors.As(err, &escrowed) {
+		return err
+	}
+
+	if err := k.handle(receipt); err != nil && !errors.As(err, &escrowed) {
+		return err
+	}
+
+	if k.accepted(event.Serder) {
+		return nil
+	}
+
+	return err
+}
+
+// handle processes an event, escrowing it if it cannot yet be accepted, and retries the escrows
+func (k *Kevery) handle(event *SignedEvent) error {
+	if event.Serder.Ilk() != cesrgo.Ilk_RCT {
+		event = k.collect(event)
+	}
+
+	if err := k.process(event); err != nil {
+		var escrowed *EscrowError
+		if !errors.As(err, &escrowed) {
+			return err
+		}
+
+		// escrowed receipts or approvals may complete the event
+		k.escrows[escrowed.Escrow] = append(k.escrows[escrowed.Escrow], event)
+		k.retry()
+
+		if !k.accepted(event.Serder) {
+			return err
+		}
+	}
+
+	k.retry()
+
+	return nil
+}
+
+// accepted reports whether serder is in the log of its prefix
+func (k *Kevery) accepted(serder *SerderKERI) bool {
+	if serder.Ilk() == cesrgo.Ilk_RCT {
+		return false
+	}
+
+	kever, ok := k.kevers[types.Qb64(serder.Pre())]
+	if !ok {
+		return false
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return false
+	}
+
+	event, ok := kever.Event(sn.Uint64())
+
+	return ok && event.Serder.Said() == serder.Said()
+}
+
+// collect removes any escrowed copy of the event, merging its attachments into event
+func (k *Kevery) collect(event *SignedEvent) *SignedEvent {
+	said := event.Serder.Said()
+
+	for _, name := range ESCROWS {
+		k.escrows[name] = slices.DeleteFunc(k.escrows[name], func(held *SignedEvent) bool {
+			if held.Serder.Said() != said || held.Serder.Ilk() == cesrgo.Ilk_RCT {
+				return false
+			}
+
+			event.Sigers = mergeSigers(held.Sigers, event.Sigers)
+			event.Wigers = mergeSigers(held.Wigers, event.Wigers)
+
+			if event.Source == nil {
+				event.Source = held.Source
+				event.sourcePre = held.sourcePre
+			}
+
+			if event.FirstSeen == nil {
+				event.FirstSeen = held.FirstSeen
+			}
+
+			return true
+		})
+	}
+
+	return event
+}
+
+// retry reprocesses escrowed events until no more are accepted
+func (k *Kevery) retry() {
+	for progress := true; progress; {
+		progress = false
+
+		for _, name := range ESCROWS {
+			held := k.escrows[name]
+			k.escrows[name] = nil
+
+			for _, event := range held {
+				err := k.process(event)
+
+				var escrowed *EscrowError
+				switch {
+				case err == nil:
+					progress = true
+				case errors.As(err, &escrowed):
+					k.escrows[escrowed.Escrow] = append(k.escrows[escrowed.Escrow], event)
+				}
+			}
+		}
+	}
+}
+
+func (k *Kevery) process(event *SignedEvent) error {
+	serder := event.Serder
+	pre := types.Qb64(serder.Pre())
+
+	if serder.Ilk() == cesrgo.Ilk_RCT {
+		return k.receipt(event)
+	}
+
+	kever, ok := k.kevers[pre]
+	if !ok {
+		ilk := serder.Ilk()
+		if ilk != cesrgo.Ilk_ICP && ilk != cesrgo.Ilk_DIP {
+			return escrow(Escrow_OutOfOrder, "unknown prefix: %s", pre)
+		}
+
+		state, err := NewKeyState(serder)
+		if err != nil {
+			return err
+		}
+
+		if err := k.validate(state, event); err != nil {
+			return err
+		}
+
+		kever := &Kever{}
+		if err := kever.accept(event, state); err != nil {
+			return err
+		}
+
+		k.kevers[pre] = kever
+
+		return nil
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return err
+	}
+
+	current := &kever.State().Sn
+	switch {
+	case sn.Cmp(current) <= 0:
+		return k.conflict(kever, event, sn)
+	case sn.Cmp(new(big.Int).Add(current, big.NewInt(1))) > 0:
+		return escrow(Escrow_OutOfOrder, "sn %s ahead of %s", sn.Text(16), current.Text(16))
+	}
+
+	state, err := kever.State().Apply(serder)
+	if err != nil {
+		return err
+	}
+
+	if err := k.validate(state, event); err != nil {
+		return err
+	}
+
+	return kever.accept(event, state)
+}
+
+// receipt verifies the receipting signatures of a receipt against the receipted event, which may be
+// accepted or escrowed, and collects the valid ones on it. Receipts by witnesses count toward the
+// event's witness threshold.
+func (k *Kevery) receipt(receipt *SignedEvent) error {
+	rct := receipt.Serder
+
+	sn, err := rct.Sn()
+	if err != nil {
+		return err
+	}
+
+	target := k.receipted(types.Qb64(rct.Pre()), sn, rct.Said())
+	if target == nil {
+		return escrow(Escrow_UnverifiedReceipt, "receipted event not yet seen: %s", rct.Said())
+	}
+
+	verified, pending, err := k.receipts(target, receipt.Cigars, receipt.Vrcs)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		receipt.Cigars = nil
+		receipt.Vrcs = pending
+
+		return escrow(Escrow_UnverifiedReceipt, "%d receipts by unknown validators", len(pending))
+	}
+
+	if verified == 0 {
+		return fmt.Errorf("no valid receipts for %s", rct.Said())
+	}
+
+	return nil
+}
+
+// receipts verifies receipting signatures against target, an accepted or escrowed event, and collects
+// the valid ones on it. It returns the number verified and the receipts by validators not yet known.
+func (k *Kevery) receipts(target *SignedEvent, cigars []*Cigar, vrcs []TransIdxSigGroup) (int, []TransIdxSigGroup, error) {
+	verified := 0
+	for _, cigar := range cigars {
+		verfer := cigar.GetVerfer()
+		if verfer == nil {
+			continue
+		}
+
+		ok, err := verfer.Verify(cigar.GetRaw(), target.Serder.GetRaw())
+		if err != nil || !ok {
+			continue
+		}
+
+		verified++
+		if !slices.ContainsFunc(target.Cigars, func(c *Cigar) bool { return sameMatter(c.GetVerfer(), verfer) }) {
+			target.Cigars = append(target.Cigars, cigar)
+		}
+	}
+
+	pending := []TransIdxSigGroup{}
+	for _, vrc := range vrcs {
+		validator, err := vrc.Prefixer.Qb64()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		kever, ok := k.kevers[validator]
+		if !ok {
+			pending = append(pending, vrc)
+			continue
+		}
+
+		estSn := vrc.Seqner.Sn()
+		est, ok := kever.Event(estSn.Uint64())
+		if !ok || !est.Serder.Estive() || !sameSaid(vrc.Saider, est.Serder.Said()) {
+			continue
+		}
+
+		state := kever.states[estSn.Uint64()]
+		sigers, err := verifySigers(target.Serder, state.Verfers, state.Tholder, vrc.Sigers)
+		if err != nil {
+			continue
+		}
+
+		verified++
+		if !slices.ContainsFunc(target.Vrcs, func(v TransIdxSigGroup) bool { return sameMatter(v.Prefixer, vrc.Prefixer) }) {
+			vrc.Sigers = sigers
+			target.Vrcs = append(target.Vrcs, vrc)
+		}
+	}
+
+	return verified, pending, nil
+}
+
+// receipted finds the accepted or escrowed event of pre at sn with said
+func (k *Kevery) receipted(pre types.Qb64, sn *big.Int, said string) *SignedEvent {
+	if kever, ok := k.kevers[pre]; ok {
+		if event, ok := kever.Event(sn.Uint64()); ok && event.Serder.Said() == said {
+			return event
+		}
+	}
+
+	for _, name := range ESCROWS {
+		for _, event := range k.escrows[name] {
+			serder := event.Serder
+			if serder.Ilk() != cesrgo.Ilk_RCT && serder.Said() == said && types.Qb64(serder.Pre()) == pre {
+				return event
+			}
+		}
+	}
+
+	return nil
+}
+
+// WitnessStatus reports how many of its witnesses have signed or receipted the accepted event of pre at
+// sn, and the witness threshold of the event
+func (k *Kevery) WitnessStatus(pre types.Qb64, sn uint64) (int, int, error) {
+	kever, ok := k.kevers[pre]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown prefix: %s", pre)
+	}
+
+	event, ok := kever.Event(sn)
+	if !ok {
+		return 0, 0, fmt.Errorf("no event at sn %x", sn)
+	}
+
+	state := kever.states[sn]
+
+	count, err := witnessed(state.Wits, event)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, state.Toad, nil
+}
+
+// FullyWitnessed reports whether the accepted event of pre at sn meets its witness threshold
+func (k *Kevery) FullyWitnessed(pre types.Qb64, sn uint64) bool {
+	count, toad, err := k.WitnessStatus(pre, sn)
+	return err == nil && count >= toad
+}
+
+func sameMatter(a, b types.Matter) bool {
+	aQb64, aErr := a.Qb64()
+	bQb64, bErr := b.Qb64()
+
+	return aErr == nil && bErr == nil && aQb64 == bQb64
+}
+
+func sameSaid(saider *Saider, said string) bool {
+	qb64, err := saider.Qb64()
+	return err == nil && string(qb64) == said
+}
+
+// conflict handles an event at an already accepted sequence number. The verified attachments of a
+// duplicate of the accepted event are merged into it. A rotation replacing an interaction that follows the last establishment event is a
+// recovery, and supersedes the interaction and everything after it. Any other validly signed event is
+// recorded as likely duplicity.
+func (k *Kevery) conflict(kever *Kever, event *SignedEvent, sn *big.Int) error {
+	serder := event.Serder
+
+	first, _ := kever.Event(sn.Uint64())
+	if first.Serder.Said() == serder.Said() {
+		return k.merge(first, event, kever.states[sn.Uint64()])
+	}
+
+	var (
+		state *KeyState
+		err   error
+	)
+
+	if sn.Sign() == 0 {
+		state, err = NewKeyState(serder)
+	} else {
+		state, err = kever.states[sn.Uint64()-1].Apply(serder)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	duplicity := &Duplicity{
+		Kind:        Duplicity_Likely,
+		Pre:         kever.Pre(),
+		Sn:          *sn,
+		First:       first,
+		Conflicting: event,
+	}
+
+	recovery := (serder.Ilk() == cesrgo.Ilk_ROT || serder.Ilk() == cesrgo.Ilk_DRT) &&
+		first.Serder.Ilk() == cesrgo.Ilk_IXN &&
+		sn.Cmp(&kever.State().EstSn) > 0
+
+	if recovery {
+		if err := k.validate(state, event); err != nil {
+			return err
+		}
+
+		duplicity.Kind = Duplicity_Recovery
+		duplicity.Superseded = slices.Clone(kever.kel[sn.Uint64():])
+
+		if err := kever.accept(event, state); err != nil {
+			return err
+		}
+
+		k.record(duplicity)
+
+		return nil
+	}
+
+	verified, err := state.verify(serder, event.Sigers)
+	if err != nil {
+		return fmt.Errorf("conflicting event not authorized: %w", err)
+	}
+
+	event.Sigers = verified
+	k.record(duplicity)
+
+	return &DuplicityError{Duplicity: duplicity}
+}
+
+// merge adds the signatures and receipts of event, a later copy of the accepted event first, that
+// verify against state to first, so that an event accepted with some of its signatures may be completed
+func (k *Kevery) merge(first, event *SignedEvent, state *KeyState) error {
+	sigers, _ := verifySigers(first.Serder, state.Verfers, state.Tholder, event.Sigers)
+	first.Sigers = mergeSigers(first.Sigers, sigers)
+
+	candidate := &SignedEvent{Serder: first.Serder, Wigers: event.Wigers}
+	if _, err := witnessed(state.Wits, candidate); err != nil {
+		return err
+	}
+
+	first.Wigers = mergeSigers(first.Wigers, candidate.Wigers)
+
+	_, pending, err := k.receipts(first, event.Cigars, event.Vrcs)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		rct, err := Receipt(first.Serder)
+		if err != nil {
+			return err
+		}
+
+		held := &SignedEvent{Serder: rct, Vrcs: pending}
+		k.escrows[Escrow_UnverifiedReceipt] = append(k.escrows[Escrow_UnverifiedReceipt], held)
+	}
+
+	return nil
+}
+
+// record keeps the evidence of a duplicity, once per conflicting event
+func (k *Kevery) record(duplicity *Duplicity) {
+	said := duplicity.Conflicting.Serder.Said()
+
+	for _, recorded := range k.duplicities[duplicity.Pre] {
+		if recorded.Conflicting.Serder.Said() == said {
+			return
+		}
+	}
+
+	k.duplicities[duplicity.Pre] = append(k.duplicities[duplicity.Pre], duplicity)
+}
+
+// Duplicities lists the conflicting events recorded for pre
+func (k *Kevery) Duplicities(pre types.Qb64) []*Duplicity {
+	return k.duplicities[pre]
+}
+
+// validate checks the attachments of an event against the state it produces
+func (k *Kevery) validate(state *KeyState, event *SignedEvent) error {
+	serder := event.Serder
+
+	if err := k.delegated(state, event); err != nil {
+		return err
+	}
+
+	verified, err := state.verify(serder, event.Sigers)
+	event.Sigers = verified
+	if err != nil {
+		return &EscrowError{Escrow: Escrow_PartiallySigned, Err: err}
+	}
+
+	count, err := witnessed(state.Wits, event)
+	if err != nil {
+		return err
+	}
+
+	if count < state.Toad {
+		return escrow(Escrow_PartiallyWitnessed, "%d of %d witnesses", count, state.Toad)
+	}
+
+	return nil
+}
+
+// delegated checks that the delegator of a delegated establishment event has approved it by anchoring
+// its seal. A delegator with the DoNotDelay (do not delegate) trait approves nothing. The traits of the
+// delegate are its own choice, so none of them waive approval.
+func (k *Kevery) delegated(state *KeyState, event *SignedEvent) error {
+	serder := event.Serder
+	if event.sourcePre != "" && (!state.Delegated() || event.sourcePre != *state.Delegator) {
+		return fmt.Errorf("source seal names %s, which is not the delegator", event.sourcePre)
+	}
+
+	if !state.Delegated() || !serder.Estive() {
+		return nil
+	}
+
+	delegator, ok := k.kevers[*state.Delegator]
+	if !ok {
+		return escrow(Escrow_MissingDelegator, "unknown delegator: %s", *state.Delegator)
+	}
+
+	if slices.Contains(delegator.State().Traits, cesrgo.Trait_DoNotDelay) {
+		return fmt.Errorf("delegator does not delegate: %s", *state.Delegator)
+	}
+
+	if event.Source != nil {
+		sn := event.Source.Seqner.Sn()
+
+		said, err := event.Source.Saider.Qb64()
+		if err != nil {
+			return err
+		}
+
+		anchor, ok := delegator.Event(sn.Uint64())
+		if !ok || types.Qb64(anchor.Serder.Said()) != said {
+			return escrow(Escrow_PartiallyDelegated, "delegator event not yet seen: sn %s", sn.Text(16))
+		}
+
+		if !anchors(anchor.Serder, serder) {
+			return fmt.Errorf("delegator event %s does not anchor %s", said, serder.Said())
+		}
+
+		return nil
+	}
+
+	for sn := len(delegator.kel) - 1; sn >= 0; sn-- {
+		anchor := delegator.kel[sn].Serder
+		if !anchors(anchor, serder) {
+			continue
+		}
+
+		//nolint:gosec
+		seqner, err := NewSeqner(big.NewInt(int64(sn)), nil)
+		if err != nil {
+			return err
+		}
+
+		saider, err := NewSaider(nil, nil, nil, options.WithQb64(types.Qb64(anchor.Said())))
+		if err != nil {
+			return err
+		}
+
+		event.Source = &SealSourceCouple{Seqner: seqner, Saider: saider}
+
+		return nil
+	}
+
+	return escrow(Escrow_PartiallyDelegated, "awaiting approval by delegator %s", *state.Delegator)
+}
+
+// anchors reports whether the seals of anchor include the event seal of serder
+func anchors(anchor, serder *SerderKERI) bool {
+	seals, err := anchor.Seals()
+	if err != nil {
+		return false
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return false
+	}
+
+	for _, seal := range seals {
+		i, _ := seal.Get("i")
+		s, _ := seal.Get("s")
+		d, _ := seal.Get("d")
+
+		snh, ok := s.(string)
+		if !ok {
+			continue
+		}
+
+		sealSn, ok := new(big.Int).SetString(snh, 16)
+		if ok && i == serder.Pre() && d == serder.Said() && sealSn.Cmp(sn) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// witnessed verifies witness signatures, indexed into wits, and counts the distinct witnesses that have
+// signed or receipted the event
+func witnessed(wits []types.Qb64, event *SignedEvent) (int, error) {
+	verfers := make([]*Verfer, len(wits))
+	for i, wit := range wits {
+		verfer, err := NewVerfer(options.WithQb64(wit))
+		if err != nil {
+			return 0, err
+		}
+
+		verfers[i] = verfer
+	}
+
+	verified := []*Siger{}
+	indices := []int{}
+
+	for _, wiger := range event.Wigers {
+		index := int(wiger.GetIndex())
+		if index >= len(verfers) || slices.Contains(indices, index) {
+			continue
+		}
+
+		ok, err := verfers[index].Verify(wiger.GetRaw(), event.Serder.GetRaw())
+		if err != nil || !ok {
+			continue
+		}
+
+		wiger.verfer = verfers[index]
+		verified = append(verified, wiger)
+		indices = append(indices, index)
+	}
+
+	event.Wigers = verified
+
+	for _, cigar := range event.Cigars {
+		qb64, err := cigar.GetVerfer().Qb64()
+		if err != nil {
+			return 0, err
+		}
+
+		index := slices.Index(wits, qb64)
+		if index >= 0 && !slices.Contains(indices, index) {
+			indices = append(indices, index)
+		}
+	}
+
+	return len(indices), nil
+}
+
+// mergeSigers combines signature lists, keeping one signature per index
+func mergeSigers(held, sigers []*Siger) []*Siger {
+	merged := slices.Clone(held)
+	for _, siger := range sigers {
+		if !slices.ContainsFunc(merged, func(s *Siger) bool { return s.GetIndex() == siger.GetIndex() }) {
+			merged = append(merged, siger)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,433 @@
+package cesr
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/jasoncolburne/cesrgo"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// KeyState is the state of an identifier after its latest accepted event
+type KeyState struct {
+	Pre types.Qb64
+	Sn  big.Int
+	Dig types.Qb64
+	Ilk types.Ilk
+
+	Tholder     *Tholder
+	Verfers     []*Verfer
+	NextTholder *Tholder
+	NextDigers  []*Diger
+
+	// sequence number and digest of the latest establishment event
+	EstSn  big.Int
+	EstDig types.Qb64
+
+	Toad int
+	Wits []types.Qb64
+
+	Traits    []types.Trait
+	Delegator *types.Qb64
+
+	Version types.Version
+	Kind    types.Kind
+
+	// the next key commitments of the state a rotation follows, which the keys signing it must expose
+	priorNextTholder *Tholder
+	priorNextDigers  []*Diger
+}
+
+// NewKeyState establishes the state created by an inception event
+func NewKeyState(serder *SerderKERI) (*KeyState, error) {
+	ilk := serder.Ilk()
+	if ilk != cesrgo.Ilk_ICP && ilk != cesrgo.Ilk_DIP {
+		return nil, fmt.Errorf("expected inception, got %s", ilk)
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return nil, err
+	}
+
+	if sn.Sign() != 0 {
+		return nil, fmt.Errorf("invalid inception sn: %s", sn.Text(16))
+	}
+
+	ks := &KeyState{
+		Pre:     types.Qb64(serder.Pre()),
+		Version: serder.GetVersion(),
+		Kind:    serder.GetKind(),
+	}
+
+	if err := verifyPrefix(serder); err != nil {
+		return nil, err
+	}
+
+	if ks.Wits, err = serder.qb64sField("b"); err != nil {
+		return nil, err
+	}
+
+	if err := validateWits(ks.Wits); err != nil {
+		return nil, err
+	}
+
+	if ks.Traits, err = eventTraits(serder); err != nil {
+		return nil, err
+	}
+
+	if ilk == cesrgo.Ilk_DIP {
+		delegator, err := serder.Delegator()
+		if err != nil {
+			return nil, err
+		}
+
+		di, err := delegator.Qb64()
+		if err != nil {
+			return nil, err
+		}
+
+		ks.Delegator = &di
+	}
+
+	if err := ks.establish(serder, ks.Wits); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// verifyPrefix checks that the prefix of an inception is derived from it: a self-addressing prefix is
+// its SAID, and a basic prefix is its only key
+func verifyPrefix(serder *SerderKERI) error {
+	prefixer, err := serder.Prefixer()
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(codex.DigCodex, prefixer.GetCode()) {
+		if serder.Pre() != serder.Said() {
+			return fmt.Errorf("self-addressing prefix is not the said: %s", serder.Pre())
+		}
+
+		return nil
+	}
+
+	keys, err := serder.stringsField("k")
+	if err != nil {
+		return err
+	}
+
+	if len(keys) != 1 || keys[0] != serder.Pre() {
+		return fmt.Errorf("basic prefix is not the only key: %s", serder.Pre())
+	}
+
+	if slices.Contains(codex.NonTransCodex, prefixer.GetCode()) {
+		ndigs, err := serder.stringsField("n")
+		if err != nil {
+			return err
+		}
+
+		if len(ndigs) > 0 {
+			return fmt.Errorf("non-transferable prefix with next key digests: %s", serder.Pre())
+		}
+	}
+
+	return nil
+}
+
+// eventTraits returns the configuration traits of an establishment event, c
+func eventTraits(serder *SerderKERI) ([]types.Trait, error) {
+	traitors, err := serder.Traitors()
+	if err != nil {
+		return nil, err
+	}
+
+	var traits []types.Trait
+	for _, traitor := range traitors {
+		trait, err := traitor.Trait()
+		if err != nil {
+			return nil, err
+		}
+
+		traits = append(traits, trait)
+	}
+
+	return traits, nil
+}
+
+// Delegated reports whether the identifier was created by a delegated inception
+func (ks *KeyState) Delegated() bool {
+	return ks.Delegator != nil
+}
+
+// EstOnly reports whether the identifier is restricted to establishment events
+func (ks *KeyState) EstOnly() bool {
+	return slices.Contains(ks.Traits, cesrgo.Trait_EstOnly)
+}
+
+// Apply validates serder as the next event of the identifier and returns the resulting state. The
+// receiver is not modified.
+func (ks *KeyState) Apply(serder *SerderKERI) (*KeyState, error) {
+	if types.Qb64(serder.Pre()) != ks.Pre {
+		return nil, fmt.Errorf("prefix mismatch: %s != %s", serder.Pre(), ks.Pre)
+	}
+
+	if len(ks.NextDigers) == 0 {
+		return nil, fmt.Errorf("identifier is non-transferable or abandoned: %s", ks.Pre)
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return nil, err
+	}
+
+	expected := new(big.Int).Add(&ks.Sn, big.NewInt(1))
+	if sn.Cmp(expected) != 0 {
+		return nil, fmt.Errorf("out of order event: sn %s, expected %s", sn.Text(16), expected.Text(16))
+	}
+
+	prior, err := serder.stringField("p")
+	if err != nil {
+		return nil, err
+	}
+
+	if types.Qb64(prior) != ks.Dig {
+		return nil, fmt.Errorf("prior digest mismatch: %s != %s", prior, ks.Dig)
+	}
+
+	next := *ks
+	next.priorNextTholder = nil
+	next.priorNextDigers = nil
+
+	switch ilk := serder.Ilk(); ilk {
+	case cesrgo.Ilk_ROT, cesrgo.Ilk_DRT:
+		if (ilk == cesrgo.Ilk_DRT) != ks.Delegated() {
+			return nil, fmt.Errorf("unexpected %s for delegated = %t", ilk, ks.Delegated())
+		}
+
+		// the listed keys must expose the prior next keys, and so must those that sign
+		verfers, err := serder.Verfers()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := exposes(ks.NextDigers, ks.NextTholder, verfers); err != nil {
+			return nil, err
+		}
+
+		next.priorNextTholder = ks.NextTholder
+		next.priorNextDigers = ks.NextDigers
+
+		// configuration traits are fixed at inception, so a v2 rotation may not carry any
+		if slices.Contains(KERIFields[serder.GetVersion().Major][ilk], "c") {
+			traits, err := eventTraits(serder)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(traits) > 0 {
+				return nil, fmt.Errorf("rotation may not change configuration traits: %v", traits)
+			}
+		}
+
+		cuts, err := serder.qb64sField("br")
+		if err != nil {
+			return nil, err
+		}
+
+		adds, err := serder.qb64sField("ba")
+		if err != nil {
+			return nil, err
+		}
+
+		wits, err := rotateWits(ks.Wits, cuts, adds)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := next.establish(serder, wits); err != nil {
+			return nil, err
+		}
+	case cesrgo.Ilk_IXN:
+		if ks.EstOnly() {
+			return nil, fmt.Errorf("interaction not permitted for establishment only identifier")
+		}
+	default:
+		return nil, fmt.Errorf("unexpected ilk for subsequent event: %s", ilk)
+	}
+
+	next.Sn = *sn
+	next.Dig = types.Qb64(serder.Said())
+	next.Ilk = serder.Ilk()
+
+	return &next, nil
+}
+
+// establish adopts the key configuration of an establishment event
+func (ks *KeyState) establish(serder *SerderKERI, wits []types.Qb64) error {
+	var err error
+
+	if ks.Verfers, err = serder.Verfers(); err != nil {
+		return err
+	}
+
+	if ks.Tholder, err = serder.Tholder(); err != nil {
+		return err
+	}
+
+	//nolint:gosec
+	if int(ks.Tholder.Size()) > len(ks.Verfers) {
+		return fmt.Errorf("threshold size %d exceeds %d keys", ks.Tholder.Size(), len(ks.Verfers))
+	}
+
+	if ks.NextDigers, err = serder.NextDigers(); err != nil {
+		return err
+	}
+
+	if ks.NextTholder, err = serder.NextTholder(); err != nil {
+		return err
+	}
+
+	//nolint:gosec
+	if int(ks.NextTholder.Size()) > len(ks.NextDigers) {
+		return fmt.Errorf("next threshold size %d exceeds %d digests", ks.NextTholder.Size(), len(ks.NextDigers))
+	}
+
+	number, err := serder.BackerNumber()
+	if err != nil {
+		return err
+	}
+
+	toad := number.Number()
+	if !toad.IsInt64() {
+		return fmt.Errorf("invalid toad: %s", toad.Text(16))
+	}
+
+	toadInt := int(toad.Int64())
+	if ks.Toad, err = eventToad(&toadInt, len(wits)); err != nil {
+		return err
+	}
+
+	sn, err := serder.Sn()
+	if err != nil {
+		return err
+	}
+
+	ks.Sn = *sn
+	ks.Dig = types.Qb64(serder.Said())
+	ks.Ilk = serder.Ilk()
+	ks.EstSn = *sn
+	ks.EstDig = ks.Dig
+	ks.Wits = wits
+
+	return nil
+}
+
+// exposes checks that verfers, the keys of a rotation, expose prior next key digests satisfying the prior
+// next threshold
+func exposes(digers []*Diger, tholder *Tholder, verfers []*Verfer) error {
+	indices, err := exposed(digers, verfers)
+	if err != nil {
+		return err
+	}
+
+	if !tholder.Satisfy(indices) {
+		return fmt.Errorf("rotation keys do not satisfy prior next threshold")
+	}
+
+	return nil
+}
+
+// verify verifies sigers against the keys of the state, returning those that verify. The verified
+// signatures must satisfy the threshold and, for a rotation, their keys must expose the prior next keys.
+func (ks *KeyState) verify(serder *SerderKERI, sigers []*Siger) ([]*Siger, error) {
+	verified, err := verifySigers(serder, ks.Verfers, ks.Tholder, sigers)
+	if err != nil {
+		return verified, err
+	}
+
+	if ks.priorNextTholder == nil {
+		return verified, nil
+	}
+
+	verfers := make([]*Verfer, len(verified))
+	for i, siger := range verified {
+		verfers[i] = siger.verfer
+	}
+
+	if err := exposes(ks.priorNextDigers, ks.priorNextTholder, verfers); err != nil {
+		return verified, fmt.Errorf("signing keys do not satisfy prior next threshold: %w", err)
+	}
+
+	return verified, nil
+}
+
+// exposed lists the indices of the digests in digers whose keys appear in verfers
+func exposed(digers []*Diger, verfers []*Verfer) ([]types.Index, error) {
+	indices := []types.Index{}
+	for _, verfer := range verfers {
+		key, err := verfer.Qb64()
+		if err != nil {
+			return nil, err
+		}
+
+		for i, diger := range digers {
+			ok, err := diger.Verify([]byte(key))
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				//nolint:gosec
+				indices = append(indices, types.Index(i))
+				break
+			}
+		}
+	}
+
+	return indices, nil
+}
+
+// rotateWits removes cuts from and appends adds to wits
+func rotateWits(wits, cuts, adds []types.Qb64) ([]types.Qb64, error) {
+	for i, cut := range cuts {
+		if slices.Contains(cuts[:i], cut) {
+			return nil, fmt.Errorf("duplicate cut: %s", cut)
+		}
+
+		if !slices.Contains(wits, cut) {
+			return nil, fmt.Errorf("cut is not a witness: %s", cut)
+		}
+	}
+
+	for i, add := range adds {
+		if slices.Contains(adds[:i], add) {
+			return nil, fmt.Errorf("duplicate add: %s", add)
+		}
+
+		if slices.Contains(cuts, add) {
+			return nil, fmt.Errorf("witness both cut and added: %s", add)
+		}
+
+		if slices.Contains(wits, add) {
+			return nil, fmt.Errorf("added witness already present: %s", add)
+		}
+	}
+
+	rotated := []types.Qb64{}
+	for _, wit := range wits {
+		if !slices.Contains(cuts, wit) {
+			rotated = append(rotated, wit)
+		}
+	}
+
+	rotated = append(rotated, adds...)
+	if err := validateWits(rotated); err != nil {
+		return nil, err
+	}
+
+	return rotated, nil
+}
@@ -199,7 +199,7 @@ func minfil(m types.Matter) (types.Qb64b, error) {
 
 func mbexfil(m types.Matter, qb2 types.Qb2) error {
 	if len(qb2) == 0 {
-		return fmt.Errorf("qb2 is empty")
+		return common.NewShortageError(1)
 	}
 
 	sextets, err := common.NabSextets(qb2, 1)
@@ -215,7 +215,7 @@ func mbexfil(m types.Matter, qb2 types.Qb2) error {
 
 	bhs := int(math.Ceil(float64(hs) * 3 / 4))
 	if len(qb2) < bhs {
-		return fmt.Errorf("insufficient material for hard part of code: qb2 size = %d, bhs = %d", len(qb2), bhs)
+		return common.NewShortageError(bhs - len(qb2))
 	}
 
 	hard, err := common.CodeB2ToB64(qb2, hs)
@@ -232,7 +232,7 @@ func mbexfil(m types.Matter, qb2 types.Qb2) error {
 
 	bcs := int(math.Ceil(float64(cs) * 3 / 4))
 	if len(qb2) < bcs {
-		return fmt.Errorf("insufficient material: qb2 size = %d, bcs = %d", len(qb2), bcs)
+		return common.NewShortageError(bcs - len(qb2))
 	}
 
 	both, err := common.CodeB2ToB64(qb2, int(cs))
@@ -251,7 +251,7 @@ func mbexfil(m types.Matter, qb2 types.Qb2) error {
 	var fs uint32
 	if szg.Fs == nil {
 		if len(qb2) < bcs {
-			return fmt.Errorf("insufficient material for code: qb2 size = %d, bcs = %d", len(qb2), bcs)
+			return common.NewShortageError(bcs - len(qb2))
 		}
 
 		// u32 safe here, max length is 4 b64 octets
@@ -266,7 +266,7 @@ func mbexfil(m types.Matter, qb2 types.Qb2) error {
 
 	bfs := int(math.Ceil((float64(fs) * 3) / 4))
 	if len(qb2) < bfs {
-		return fmt.Errorf("insufficient material: qb2 size = %d, bfs = %d", len(qb2), bfs)
+		return common.NewShortageError(bfs - len(qb2))
 	}
 
 	qb2 = qb2[:bfs]
@@ -285,7 +285,7 @@ func mbexfil(m types.Matter, qb2 types.Qb2) error {
 		return fmt.Errorf("non-zeroed lead midpad bytes")
 	}
 
-	raw := types.Raw(qb2[bcs+int(szg.Ls):])
+	raw := types.Raw(slices.Clone(qb2[bcs+int(szg.Ls):]))
 
 	if len(raw) != len(qb2)-bcs-int(szg.Ls) {
 		return fmt.Errorf("improperly qualified material: qb2 = %s", qb2)
@@ -307,7 +307,7 @@ func mbexfil(m types.Matter, qb2 types.Qb2) error {
 
 func mexfil(m types.Matter, qb64 types.Qb64) error {
 	if len(qb64) == 0 {
-		return fmt.Errorf("qb64 is empty")
+		return common.NewShortageError(1)
 	}
 
 	first := qb64[0]
@@ -317,7 +317,7 @@ func mexfil(m types.Matter, qb64 types.Qb64) error {
 	}
 
 	if len(qb64) < hs {
-		return fmt.Errorf("insufficient material for hard part of code: qb64 size = %d, hs = %d", len(qb64), hs)
+		return common.NewShortageError(hs - len(qb64))
 	}
 
 	hard := qb64[:hs]
@@ -328,6 +328,10 @@ func mexfil(m types.Matter, qb64 types.Qb64) error {
 	}
 
 	cs := szg.Hs + szg.Ss
+	if len(qb64) < int(cs) {
+		return common.NewShortageError(int(cs) - len(qb64))
+	}
+
 	soft := qb64[hs : hs+int(szg.Ss)]
 	xtra := soft[:szg.Xs]
 	soft = soft[szg.Xs:]
@@ -349,7 +353,7 @@ func mexfil(m types.Matter, qb64 types.Qb64) error {
 	}
 
 	if len(qb64) < int(fs) {
-		return fmt.Errorf("insufficient material: qb64 size = %d, fs = %d", len(qb64), fs)
+		return common.NewShortageError(int(fs) - len(qb64))
 	}
 
 	qb64 = qb64[:fs]
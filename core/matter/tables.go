@@ -290,6 +290,86 @@ var SeedCodex = []types.Code{
 	Ed448_Seed,
 }
 
+var CipherCodex = []types.Code{
+	X25519_Cipher_Seed,
+	X25519_Cipher_Salt,
+	X25519_Cipher_L0,
+	X25519_Cipher_L1,
+	X25519_Cipher_L2,
+	X25519_Cipher_Big_L0,
+	X25519_Cipher_Big_L1,
+	X25519_Cipher_Big_L2,
+	X25519_Cipher_QB64_L0,
+	X25519_Cipher_QB64_L1,
+	X25519_Cipher_QB64_L2,
+	X25519_Cipher_QB64_Big_L0,
+	X25519_Cipher_QB64_Big_L1,
+	X25519_Cipher_QB64_Big_L2,
+	X25519_Cipher_QB2_L0,
+	X25519_Cipher_QB2_L1,
+	X25519_Cipher_QB2_L2,
+	X25519_Cipher_QB2_Big_L0,
+	X25519_Cipher_QB2_Big_L1,
+	X25519_Cipher_QB2_Big_L2,
+	HPKEBase_Cipher_L0,
+	HPKEBase_Cipher_L1,
+	HPKEBase_Cipher_L2,
+	HPKEBase_Cipher_Big_L0,
+	HPKEBase_Cipher_Big_L1,
+	HPKEBase_Cipher_Big_L2,
+	HPKEAuth_Cipher_L0,
+	HPKEAuth_Cipher_L1,
+	HPKEAuth_Cipher_L2,
+	HPKEAuth_Cipher_Big_L0,
+	HPKEAuth_Cipher_Big_L1,
+	HPKEAuth_Cipher_Big_L2,
+}
+
+var StreamCipherCodex = []types.Code{
+	X25519_Cipher_L0,
+	X25519_Cipher_L1,
+	X25519_Cipher_L2,
+	X25519_Cipher_Big_L0,
+	X25519_Cipher_Big_L1,
+	X25519_Cipher_Big_L2,
+}
+
+var QB64CipherCodex = []types.Code{
+	X25519_Cipher_QB64_L0,
+	X25519_Cipher_QB64_L1,
+	X25519_Cipher_QB64_L2,
+	X25519_Cipher_QB64_Big_L0,
+	X25519_Cipher_QB64_Big_L1,
+	X25519_Cipher_QB64_Big_L2,
+}
+
+var QB2CipherCodex = []types.Code{
+	X25519_Cipher_QB2_L0,
+	X25519_Cipher_QB2_L1,
+	X25519_Cipher_QB2_L2,
+	X25519_Cipher_QB2_Big_L0,
+	X25519_Cipher_QB2_Big_L1,
+	X25519_Cipher_QB2_Big_L2,
+}
+
+var HPKEBaseCipherCodex = []types.Code{
+	HPKEBase_Cipher_L0,
+	HPKEBase_Cipher_L1,
+	HPKEBase_Cipher_L2,
+	HPKEBase_Cipher_Big_L0,
+	HPKEBase_Cipher_Big_L1,
+	HPKEBase_Cipher_Big_L2,
+}
+
+var HPKEAuthCipherCodex = []types.Code{
+	HPKEAuth_Cipher_L0,
+	HPKEAuth_Cipher_L1,
+	HPKEAuth_Cipher_L2,
+	HPKEAuth_Cipher_Big_L0,
+	HPKEAuth_Cipher_Big_L1,
+	HPKEAuth_Cipher_Big_L2,
+}
+
 var SMALL_VRZ_DEX = []rune{'4', '5', '6'}
 var LARGE_VRZ_DEX = []rune{'7', '8', '9'}
 var SMALL_VRZ_BYTES = uint32(3)
@@ -0,0 +1,692 @@
+package cesr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
+	"github.com/jasoncolburne/cesrgo/core/counter/options"
+	"github.com/jasoncolburne/cesrgo/core/counter/two"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	mopts "github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// field orders for messages serialized with a FixBodyGroup, where labels are implied by the ilk
+var nativeFixedFields = KERIFields[cesrgo.VERSION_2_0.Major]
+
+// labels of fields holding qb64 primitives, whose strings are written as the primitive itself. Strings in
+// any other field are text.
+var nativePrimitiveFields = []string{"d", "i", "p", "di", "k", "n", "b", "br", "ba", "u", "ri", "rd", "a", "A", "e", "r"}
+
+// the largest count a small (two character) v2 group code can carry
+const maxSmallCount = 64*64 - 1
+
+// MarshalNative serializes a message body in native CESR text. Fields are encoded as primitives chosen by
+// label: 'v' as a Verser, 't' as an Ilker, 's' and 'bt' as Numbers, 'dt' as a Dater, 'kt' and 'nt' as
+// Tholder limens and 'c' as a list of Traitors. Strings in fields that hold primitives, such as 'i' and
+// 'd', are written as the qb64 primitive when they parse as one. Other strings are always a Texter. Placeholder strings of '#' are emitted verbatim, so SAIDs can
+// be computed over the native form.
+func MarshalNative(ked types.Map) (types.Raw, error) {
+	code := two.MapBodyGroup
+	if nativeFixed(ked) {
+		code = two.FixBodyGroup
+	}
+
+	body := bytes.Buffer{}
+	for _, label := range ked.Keys() {
+		value, _ := ked.Get(label)
+
+		if code == two.MapBodyGroup {
+			if err := writeNativeLabel(&body, label); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := writeNativeValue(&body, label, value); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", label, err)
+		}
+	}
+
+	return nativeGroup(code, body.Bytes())
+}
+
+// UnmarshalNative deserializes a native CESR text message body. The size recorded in the returned
+// version string is the length of the body group.
+func UnmarshalNative(raw types.Raw) (types.Map, error) {
+	e := &extractor{ims: raw}
+
+	counter, err := e.counter()
+	if err != nil {
+		return types.Map{}, err
+	}
+
+	fixed := false
+	switch counter.GetCode() {
+	case two.FixBodyGroup, two.BigFixBodyGroup:
+		fixed = true
+	case two.MapBodyGroup, two.BigMapBodyGroup:
+	default:
+		return types.Map{}, fmt.Errorf("unexpected native body code: %s", counter.GetCode())
+	}
+
+	body, err := e.group(counter.GetCount())
+	if err != nil {
+		return types.Map{}, err
+	}
+
+	//nolint:gosec
+	size := types.Size(len(raw) - len(e.ims))
+
+	if !fixed {
+		return body.nativeMap(&size)
+	}
+
+	ked := types.NewMap()
+	for i := 0; len(body.ims) > 0; i++ {
+		var label string
+		switch i {
+		case 0:
+			label = "v"
+		case 1:
+			label = "t"
+		default:
+			ilk, _ := ked.Get("t")
+			ilkStr, _ := ilk.(string)
+
+			fields, ok := nativeFixedFields[types.Ilk(ilkStr)]
+			if !ok {
+				return types.Map{}, fmt.Errorf("no fixed fields for ilk: %s", ilkStr)
+			}
+
+			if i >= len(fields) {
+				return types.Map{}, fmt.Errorf("too many fields for ilk: %s", ilkStr)
+			}
+
+			label = fields[i]
+		}
+
+		value, err := body.nativeValue(label, &size)
+		if err != nil {
+			return types.Map{}, fmt.Errorf("failed to decode %s: %w", label, err)
+		}
+
+		ked.Set(label, value)
+	}
+
+	return ked, nil
+}
+
+// SmellNative reads the version and size of a native CESR message body without decoding its fields
+func SmellNative(raw types.Raw) (types.Proto, types.Version, types.Size, *types.Version, error) {
+	e := &extractor{ims: raw}
+
+	counter, err := e.counter()
+	if err != nil {
+		return "", types.Version{}, 0, nil, err
+	}
+
+	switch counter.GetCode() {
+	case two.FixBodyGroup, two.BigFixBodyGroup:
+	case two.MapBodyGroup, two.BigMapBodyGroup:
+		l, err := e.labeler()
+		if err != nil {
+			return "", types.Version{}, 0, nil, err
+		}
+
+		label, err := l.Label()
+		if err != nil {
+			return "", types.Version{}, 0, nil, err
+		}
+
+		if label != "v" {
+			return "", types.Version{}, 0, nil, fmt.Errorf("expected version field first, got %s", label)
+		}
+	default:
+		return "", types.Version{}, 0, nil, fmt.Errorf("unexpected native body code: %s", counter.GetCode())
+	}
+
+	verser, err := NewVerser(nil, nil, nil, nil, e.matterOption())
+	if err != nil {
+		return "", types.Version{}, 0, nil, err
+	}
+
+	versage, err := verser.Versage()
+	if err != nil {
+		return "", types.Version{}, 0, nil, err
+	}
+
+	qb64b, err := counter.Qb64b()
+	if err != nil {
+		return "", types.Version{}, 0, nil, err
+	}
+
+	//nolint:gosec
+	size := types.Size(len(qb64b) + int(counter.GetCount())*4)
+
+	return versage.Proto, versage.Pvrsn, size, versage.Gvrsn, nil
+}
+
+// sizeifyNative is the native counterpart of common.Sizeify. The version field carries no size in native
+// form, so the body only needs to be serialized once.
+//
+//nolint:gocritic
+func sizeifyNative(ked types.Map) (types.Raw, types.Proto, types.Kind, types.Map, types.Version, error) {
+	vAny, ok := ked.Get("v")
+	if !ok {
+		return nil, "", "", types.Map{}, types.Version{}, fmt.Errorf("version string not found")
+	}
+
+	v, ok := vAny.(string)
+	if !ok {
+		return nil, "", "", types.Map{}, types.Version{}, fmt.Errorf("version string not a string")
+	}
+
+	proto, pvrsn, _, _, gvrsn, err := common.Deversify(v)
+	if err != nil {
+		return nil, "", "", types.Map{}, types.Version{}, err
+	}
+
+	raw, err := MarshalNative(ked)
+	if err != nil {
+		return nil, "", "", types.Map{}, types.Version{}, err
+	}
+
+	if len(raw) > 1<<32-1 {
+		return nil, "", "", types.Map{}, types.Version{}, fmt.Errorf("size too large")
+	}
+
+	kind := cesrgo.Kind_CESR
+	//nolint:gosec
+	vs, err := common.Versify(&proto, &pvrsn, &kind, types.Size(len(raw)), gvrsn)
+	if err != nil {
+		return nil, "", "", types.Map{}, types.Version{}, err
+	}
+
+	ked.Set("v", vs)
+
+	return raw, proto, kind, ked, pvrsn, nil
+}
+
+func nativeFixed(ked types.Map) bool {
+	vAny, ok := ked.Get("v")
+	if !ok {
+		return false
+	}
+
+	v, ok := vAny.(string)
+	if !ok {
+		return false
+	}
+
+	proto, _, _, _, _, err := common.Deversify(v)
+	if err != nil || proto != cesrgo.Proto_KERI {
+		return false
+	}
+
+	ilk, ok := ked.Get("t")
+	if !ok {
+		return false
+	}
+
+	ilkStr, ok := ilk.(string)
+	if !ok {
+		return false
+	}
+
+	fields, ok := nativeFixedFields[types.Ilk(ilkStr)]
+
+	return ok && slices.Equal(fields, ked.Keys())
+}
+
+func nativeGroup(code types.Code, body []byte) (types.Raw, error) {
+	if len(body)%4 != 0 {
+		return nil, fmt.Errorf("native group not aligned: length = %d", len(body))
+	}
+
+	count := len(body) / 4
+	if count > maxSmallCount {
+		code = "-" + code
+	}
+
+	//nolint:gosec
+	counter, err := NewCounter(options.WithCode(code), options.WithCount(types.Count(count)))
+	if err != nil {
+		return nil, err
+	}
+
+	qb64b, err := counter.Qb64b()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Raw(append(qb64b, body...)), nil
+}
+
+func writeNativeLabel(buf *bytes.Buffer, label string) error {
+	labeler, err := NewLabeler(&label)
+	if err != nil {
+		return err
+	}
+
+	return writeNativeMatter(buf, labeler)
+}
+
+func writeNativeMatter(buf *bytes.Buffer, m interface{ Qb64b() (types.Qb64b, error) }) error {
+	qb64b, err := m.Qb64b()
+	if err != nil {
+		return err
+	}
+
+	buf.Write(qb64b)
+
+	return nil
+}
+
+//nolint:gocognit,gocyclo
+func writeNativeValue(buf *bytes.Buffer, label string, value any) error {
+	switch v := value.(type) {
+	case types.Map:
+		body := bytes.Buffer{}
+		for _, inner := range v.Keys() {
+			innerValue, _ := v.Get(inner)
+
+			if err := writeNativeLabel(&body, inner); err != nil {
+				return err
+			}
+
+			if err := writeNativeValue(&body, inner, innerValue); err != nil {
+				return err
+			}
+		}
+
+		group, err := nativeGroup(two.GenericMapGroup, body.Bytes())
+		if err != nil {
+			return err
+		}
+
+		buf.Write(group)
+
+		return nil
+	case types.List:
+		return writeNativeValue(buf, label, []any(v))
+	case []string:
+		l := make([]any, len(v))
+		for i, s := range v {
+			l[i] = s
+		}
+
+		return writeNativeValue(buf, label, l)
+	case []any:
+		// weighted thresholds are lists, but encode as a single limen
+		if label == "kt" || label == "nt" {
+			break
+		}
+
+		body := bytes.Buffer{}
+		for _, element := range v {
+			if err := writeNativeValue(&body, label, element); err != nil {
+				return err
+			}
+		}
+
+		group, err := nativeGroup(two.GenericListGroup, body.Bytes())
+		if err != nil {
+			return err
+		}
+
+		buf.Write(group)
+
+		return nil
+	}
+
+	switch label {
+	case "v":
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("version string not a string")
+		}
+
+		proto, pvrsn, _, _, gvrsn, err := common.Deversify(v)
+		if err != nil {
+			return err
+		}
+
+		verser, err := NewVerser(nil, &proto, &pvrsn, gvrsn)
+		if err != nil {
+			return err
+		}
+
+		return writeNativeMatter(buf, verser)
+	case "t":
+		ilk, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("ilk not a string")
+		}
+
+		ilker, err := NewIlker((*types.Ilk)(&ilk))
+		if err != nil {
+			return err
+		}
+
+		return writeNativeMatter(buf, ilker)
+	case "s", "bt":
+		hex, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("number not a hex string")
+		}
+
+		number, err := NewNumber(nil, &hex)
+		if err != nil {
+			return err
+		}
+
+		return writeNativeMatter(buf, number)
+	case "dt":
+		dts, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("datetime not a string")
+		}
+
+		dater, err := NewDater((*types.DateTime)(&dts))
+		if err != nil {
+			return err
+		}
+
+		return writeNativeMatter(buf, dater)
+	case "kt", "nt":
+		tholder, err := NewTholder(nil, nil, value)
+		if err != nil {
+			return err
+		}
+
+		limen, err := tholder.Limen()
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(string(limen))
+
+		return nil
+	case "c":
+		trait, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("trait not a string")
+		}
+
+		traitor, err := NewTraitor((*types.Trait)(&trait))
+		if err != nil {
+			return err
+		}
+
+		return writeNativeMatter(buf, traitor)
+	}
+
+	return writeNativeScalar(buf, label, value)
+}
+
+func writeNativeScalar(buf *bytes.Buffer, label string, value any) error {
+	var m interface{ Qb64b() (types.Qb64b, error) }
+
+	switch v := value.(type) {
+	case nil:
+		u := &UndifferentiatedMatter{}
+		if err := NewMatter(u, mopts.WithCode(codex.Null), mopts.WithRaw(types.Raw{})); err != nil {
+			return err
+		}
+
+		m = u
+	case bool:
+		code := codex.No
+		if v {
+			code = codex.Yes
+		}
+
+		u := &UndifferentiatedMatter{}
+		if err := NewMatter(u, mopts.WithCode(code), mopts.WithRaw(types.Raw{})); err != nil {
+			return err
+		}
+
+		m = u
+	case json.Number, int, int64, uint64, uint32, int32:
+		n, ok := new(big.Int).SetString(fmt.Sprint(v), 10)
+		if !ok {
+			return fmt.Errorf("not an integer: %v", v)
+		}
+
+		number, err := NewNumber(n, nil)
+		if err != nil {
+			return err
+		}
+
+		m = number
+	case string:
+		if v == "" {
+			noncer, err := NewNoncer([]byte{})
+			if err != nil {
+				return err
+			}
+
+			m = noncer
+		} else if strings.Trim(v, "#") == "" {
+			// said placeholder
+			buf.WriteString(v)
+			return nil
+		} else {
+			if slices.Contains(nativePrimitiveFields, label) {
+				u := &UndifferentiatedMatter{}
+				err := NewMatter(u, mopts.WithQb64(types.Qb64(v)))
+				if err == nil {
+					qb64b, err := u.Qb64b()
+					if err == nil && string(qb64b) == v {
+						buf.Write(qb64b)
+						return nil
+					}
+				}
+			}
+
+			texter, err := NewTexter(&v, mopts.WithCode(codex.Bytes_L0))
+			if err != nil {
+				return err
+			}
+
+			m = texter
+		}
+	default:
+		return fmt.Errorf("unsupported native value type: %T", value)
+	}
+
+	return writeNativeMatter(buf, m)
+}
+
+func (e *extractor) labeler() (*Labeler, error) {
+	l, err := NewLabeler(nil, e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (e *extractor) matter() (*UndifferentiatedMatter, error) {
+	m := &UndifferentiatedMatter{}
+	if err := NewMatter(m, e.matterOption()); err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (e *extractor) nativeMap(size *types.Size) (types.Map, error) {
+	m := types.NewMap()
+	for len(e.ims) > 0 {
+		l, err := e.labeler()
+		if err != nil {
+			return types.Map{}, err
+		}
+
+		label, err := l.Label()
+		if err != nil {
+			return types.Map{}, err
+		}
+
+		value, err := e.nativeValue(label, size)
+		if err != nil {
+			return types.Map{}, fmt.Errorf("failed to decode %s: %w", label, err)
+		}
+
+		m.Set(label, value)
+	}
+
+	return m, nil
+}
+
+//nolint:gocognit,gocyclo
+func (e *extractor) nativeValue(label string, size *types.Size) (any, error) {
+	if len(e.ims) == 0 {
+		return nil, common.NewShortageError(1)
+	}
+
+	if e.ims[0] == '-' {
+		counter, err := e.counter()
+		if err != nil {
+			return nil, err
+		}
+
+		group, err := e.group(counter.GetCount())
+		if err != nil {
+			return nil, err
+		}
+
+		switch counter.GetCode() {
+		case two.GenericMapGroup, two.BigGenericMapGroup:
+			return group.nativeMap(nil)
+		case two.GenericListGroup, two.BigGenericListGroup:
+			l := []any{}
+			for len(group.ims) > 0 {
+				value, err := group.nativeValue(label, nil)
+				if err != nil {
+					return nil, err
+				}
+
+				l = append(l, value)
+			}
+
+			return l, nil
+		default:
+			return nil, fmt.Errorf("unexpected group code in native body: %s", counter.GetCode())
+		}
+	}
+
+	m, err := e.matter()
+	if err != nil {
+		return nil, err
+	}
+
+	qb64, err := m.Qb64()
+	if err != nil {
+		return nil, err
+	}
+
+	switch label {
+	case "v":
+		if size == nil {
+			break
+		}
+
+		verser, err := NewVerser(nil, nil, nil, nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		versage, err := verser.Versage()
+		if err != nil {
+			return nil, err
+		}
+
+		kind := cesrgo.Kind_CESR
+		return common.Versify(&versage.Proto, &versage.Pvrsn, &kind, *size, versage.Gvrsn)
+	case "t":
+		ilker, err := NewIlker(nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		ilk, err := ilker.Ilk()
+		return string(ilk), err
+	case "s", "bt":
+		number, err := NewNumber(nil, nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		// the hex form of a Number is padded to its code size, message fields are not
+		n := number.Number()
+		return n.Text(16), nil
+	case "dt":
+		dater, err := NewDater(nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		dts, err := dater.DTS()
+		return string(dts), err
+	case "kt", "nt":
+		tholder, err := NewTholder(nil, &qb64, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return tholder.Sith()
+	case "c":
+		traitor, err := NewTraitor(nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		trait, err := traitor.Trait()
+		return string(trait), err
+	}
+
+	code := m.GetCode()
+	switch {
+	case code == codex.Null:
+		return nil, nil
+	case code == codex.Yes:
+		return true, nil
+	case code == codex.No:
+		return false, nil
+	case code == codex.Empty:
+		return "", nil
+	case slices.Contains(codex.NumCodex, code):
+		number, err := NewNumber(nil, nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		n := number.Number()
+		return json.Number(n.String()), nil
+	case slices.Contains(codex.TextCodex, code):
+		texter, err := NewTexter(nil, mopts.WithQb64(qb64))
+		if err != nil {
+			return nil, err
+		}
+
+		return texter.Text(), nil
+	default:
+		return string(qb64), nil
+	}
+}
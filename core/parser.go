@@ -0,0 +1,966 @@
+package cesr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
+	"github.com/jasoncolburne/cesrgo/core/counter/one"
+	"github.com/jasoncolburne/cesrgo/core/counter/options"
+	"github.com/jasoncolburne/cesrgo/core/counter/two"
+	iopts "github.com/jasoncolburne/cesrgo/core/indexer/options"
+	mopts "github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+type NonTransReceiptCouple struct {
+	Verfer *Verfer
+	Cigar  *Cigar
+}
+
+type TransReceiptQuadruple struct {
+	Prefixer *Prefixer
+	Seqner   *Seqner
+	Saider   *Saider
+	Siger    *Siger
+}
+
+type TransIdxSigGroup struct {
+	Prefixer *Prefixer
+	Seqner   *Seqner
+	Saider   *Saider
+	Sigers   []*Siger
+}
+
+type TransLastIdxSigGroup struct {
+	Prefixer *Prefixer
+	Sigers   []*Siger
+}
+
+type FirstSeenReplayCouple struct {
+	Seqner *Seqner
+	Dater  *Dater
+}
+
+type SealSourceCouple struct {
+	Seqner *Seqner
+	Saider *Saider
+}
+
+type SealSourceTriple struct {
+	Prefixer *Prefixer
+	Seqner   *Seqner
+	Saider   *Saider
+}
+
+// Message is a message body extracted from a stream together with its decoded attachments
+type Message struct {
+	Raw     types.Raw
+	Proto   types.Proto
+	Kind    types.Kind
+	Version types.Version
+	Size    types.Size
+
+	ControllerIdxSigs      []*Siger
+	WitnessIdxSigs         []*Siger
+	NonTransReceiptCouples []NonTransReceiptCouple
+	TransReceiptQuadruples []TransReceiptQuadruple
+	TransIdxSigGroups      []TransIdxSigGroup
+	TransLastIdxSigGroups  []TransLastIdxSigGroup
+	FirstSeenReplayCouples []FirstSeenReplayCouple
+	SealSourceCouples      []SealSourceCouple
+	SealSourceTriples      []SealSourceTriple
+	PathedMaterialGroups   []types.Raw
+}
+
+func (m *Message) Sadder() (*Sadder, error) {
+	raw := m.Raw
+	return NewSadder(nil, &raw, nil, nil, false)
+}
+
+// groups that may enclose messages at the top level of a stream, by genus major version
+var messageGroupCodes = map[uint32][]types.Code{
+	cesrgo.VERSION_1_0.Major: {
+		one.GenericGroup,
+		one.BigGenericGroup,
+		one.BodyWithAttachmentGroup,
+		one.BigBodyWithAttachmentGroup,
+	},
+	cesrgo.VERSION_2_0.Major: {
+		two.GenericGroup,
+		two.BigGenericGroup,
+		two.BodyWithAttachmentGroup,
+		two.BigBodyWithAttachmentGroup,
+	},
+}
+
+// native message bodies, only the text domain is supported
+var nativeBodyCodes = []types.Code{
+	two.FixBodyGroup,
+	two.BigFixBodyGroup,
+	two.MapBodyGroup,
+	two.BigMapBodyGroup,
+}
+
+// v1 attachment groups that count items rather than quadlets, mapped to their v2 equivalents
+var itemCodes1 = map[types.Code]types.Code{
+	one.ControllerIdxSigs:      two.ControllerIdxSigs,
+	one.WitnessIdxSigs:         two.WitnessIdxSigs,
+	one.NonTransReceiptCouples: two.NonTransReceiptCouples,
+	one.TransReceiptQuadruples: two.TransReceiptQuadruples,
+	one.FirstSeenReplayCouples: two.FirstSeenReplayCouples,
+	one.TransIdxSigGroups:      two.TransIdxSigGroups,
+	one.TransLastIdxSigGroups:  two.TransLastIdxSigGroups,
+	one.SealSourceCouples:      two.SealSourceCouples,
+	one.SealSourceTriples:      two.SealSourceTriples,
+}
+
+var attachmentGroupCodes = map[uint32][]types.Code{
+	cesrgo.VERSION_1_0.Major: {
+		one.AttachmentGroup,
+		one.BigAttachmentGroup,
+		one.ControllerIdxSigs,
+		one.WitnessIdxSigs,
+		one.NonTransReceiptCouples,
+		one.TransReceiptQuadruples,
+		one.FirstSeenReplayCouples,
+		one.TransIdxSigGroups,
+		one.TransLastIdxSigGroups,
+		one.SealSourceCouples,
+		one.SealSourceTriples,
+		one.PathedMaterialGroup,
+		one.BigPathedMaterialGroup,
+	},
+	cesrgo.VERSION_2_0.Major: {
+		two.AttachmentGroup,
+		two.BigAttachmentGroup,
+		two.ControllerIdxSigs,
+		two.BigControllerIdxSigs,
+		two.WitnessIdxSigs,
+		two.BigWitnessIdxSigs,
+		two.NonTransReceiptCouples,
+		two.BigNonTransReceiptCouples,
+		two.TransReceiptQuadruples,
+		two.BigTransReceiptQuadruples,
+		two.FirstSeenReplayCouples,
+		two.BigFirstSeenReplayCouples,
+		two.TransIdxSigGroups,
+		two.BigTransIdxSigGroups,
+		two.TransLastIdxSigGroups,
+		two.BigTransLastIdxSigGroups,
+		two.SealSourceCouples,
+		two.BigSealSourceCouples,
+		two.SealSourceTriples,
+		two.BigSealSourceTriples,
+		two.PathedMaterialGroup,
+		two.BigPathedMaterialGroup,
+	},
+}
+
+const readSize = 4096
+
+// Parser extracts messages from a stream. Partial input is retained between calls, so a network reader can
+// feed chunks as they arrive and parsing resumes where it left off.
+type Parser struct {
+	reader  io.Reader
+	ims     []byte
+	closed  bool
+	pending *Message
+	version types.Version
+}
+
+// NewParser creates a parser that pulls from reader as needed. With a nil reader, input is supplied with
+// Feed and the end of the stream is signaled with Close. Counters are read against the cesrgo.VERSION
+// code table until a message or a KERIACDCGenusVersion counter selects another.
+func NewParser(reader io.Reader) *Parser {
+	return &Parser{reader: reader, version: cesrgo.VERSION}
+}
+
+func (p *Parser) Feed(ims []byte) {
+	p.ims = append(p.ims, ims...)
+}
+
+// Close marks the end of the stream, releasing a final message that may still be awaiting attachments
+func (p *Parser) Close() {
+	p.closed = true
+}
+
+// Next returns the next message in the stream, or io.EOF once the stream is exhausted. When fed manually,
+// a *common.ShortageError indicates that more input is needed before the next message can be returned.
+func (p *Parser) Next() (*Message, error) {
+	for {
+		msg, err := p.step()
+
+		var shortage *common.ShortageError
+		if errors.As(err, &shortage) && p.reader != nil && !p.closed {
+			if err := p.fill(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		return msg, err
+	}
+}
+
+func (p *Parser) ParseAll() ([]*Message, error) {
+	msgs := []*Message{}
+
+	for {
+		msg, err := p.Next()
+		if errors.Is(err, io.EOF) {
+			return msgs, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, msg)
+	}
+}
+
+func (p *Parser) fill() error {
+	chunk := make([]byte, readSize)
+	n, err := p.reader.Read(chunk)
+	p.ims = append(p.ims, chunk[:n]...)
+
+	if errors.Is(err, io.EOF) {
+		p.closed = true
+		return nil
+	}
+
+	return err
+}
+
+func (p *Parser) step() (*Message, error) {
+	if p.pending == nil {
+		msg, err := p.coldStart()
+		if err != nil {
+			return nil, err
+		}
+
+		p.pending = msg
+	}
+
+	if err := p.extractAttachments(p.pending); err != nil {
+		return nil, err
+	}
+
+	msg := p.pending
+	p.pending = nil
+
+	return msg, nil
+}
+
+func (p *Parser) coldStart() (*Message, error) {
+	for {
+		if len(p.ims) == 0 {
+			if p.closed {
+				return nil, io.EOF
+			}
+
+			return nil, common.NewShortageError(1)
+		}
+
+		cold, err := common.Sniff(p.ims)
+		if err != nil {
+			return nil, err
+		}
+
+		switch cold {
+		case common.COLD_JSON, common.COLD_MGPK1, common.COLD_CBOR, common.COLD_MGPK2:
+			return p.extractMessage()
+		case common.COLD_CTB64, common.COLD_CTOPB2:
+			e := p.extractor(cold == common.COLD_CTOPB2)
+			counter, err := e.counter()
+			if err != nil {
+				return nil, err
+			}
+
+			if counter.GetCode() == two.KERIACDCGenusVersion {
+				if err := p.switchVersion(counter); err != nil {
+					return nil, err
+				}
+
+				p.ims = e.ims
+				continue
+			}
+
+			if p.version.Major > 1 && !e.binary && slices.Contains(nativeBodyCodes, counter.GetCode()) {
+				return p.extractNativeMessage(e, counter)
+			}
+
+			// message groups are flattened, their contents are parsed in place
+			if !slices.Contains(messageGroupCodes[p.version.Major], counter.GetCode()) {
+				return nil, fmt.Errorf("unexpected counter code at cold start: %s", counter.GetCode())
+			}
+
+			p.ims = e.ims
+		case common.COLD_OPB64:
+			return nil, fmt.Errorf("unsupported op code")
+		default:
+			return nil, fmt.Errorf("unexpected cold start: %s", cold)
+		}
+	}
+}
+
+func (p *Parser) extractMessage() (*Message, error) {
+	proto, pvrsn, kind, size, gvrsn, err := common.Smell(p.ims[:min(len(p.ims), common.SMELLSIZE)])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.ims) < int(size) {
+		return nil, common.NewShortageError(int(size) - len(p.ims))
+	}
+
+	msg := &Message{
+		Raw:     types.Raw(slices.Clone(p.ims[:size])),
+		Proto:   proto,
+		Kind:    kind,
+		Version: pvrsn,
+		Size:    size,
+	}
+
+	p.ims = p.ims[size:]
+
+	// attachments follow the code table of the message they are attached to
+	p.version = pvrsn
+	if gvrsn != nil {
+		p.version = *gvrsn
+	}
+
+	return msg, nil
+}
+
+func (p *Parser) extractNativeMessage(e *extractor, counter *Counter) (*Message, error) {
+	if _, err := e.group(counter.GetCount()); err != nil {
+		return nil, err
+	}
+
+	raw := types.Raw(slices.Clone(p.ims[:len(p.ims)-len(e.ims)]))
+
+	proto, pvrsn, size, gvrsn, err := SmellNative(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ims = e.ims
+
+	p.version = pvrsn
+	if gvrsn != nil {
+		p.version = *gvrsn
+	}
+
+	return &Message{
+		Raw:     raw,
+		Proto:   proto,
+		Kind:    cesrgo.Kind_CESR,
+		Version: pvrsn,
+		Size:    size,
+	}, nil
+}
+
+// extractAttachments consumes whole attachment groups only, so a shortage leaves the buffer positioned at
+// the start of the incomplete group
+func (p *Parser) extractAttachments(msg *Message) error {
+	for {
+		if len(p.ims) == 0 {
+			if p.closed {
+				return nil
+			}
+
+			// more attachments may follow
+			return common.NewShortageError(1)
+		}
+
+		cold, err := common.Sniff(p.ims)
+		if err != nil {
+			return err
+		}
+
+		if cold != common.COLD_CTB64 && cold != common.COLD_CTOPB2 {
+			return nil
+		}
+
+		e := p.extractor(cold == common.COLD_CTOPB2)
+		if e.binary && p.ims[0]>>2 == 0x3f {
+			// binary op code
+			return nil
+		}
+
+		counter, err := e.counter()
+		if err != nil {
+			return err
+		}
+
+		if counter.GetCode() == two.KERIACDCGenusVersion {
+			if err := p.switchVersion(counter); err != nil {
+				return err
+			}
+
+			p.ims = e.ims
+			continue
+		}
+
+		if !slices.Contains(attachmentGroupCodes[p.version.Major], counter.GetCode()) {
+			return nil
+		}
+
+		// decode into a scratch message, so a shortage leaves msg untouched
+		scratch := &Message{}
+		if err := e.attachments(scratch, counter.GetCode(), counter.GetCount()); err != nil {
+			return err
+		}
+
+		msg.merge(scratch)
+		p.ims = e.ims
+	}
+}
+
+func (p *Parser) extractor(binary bool) *extractor {
+	return &extractor{ims: p.ims, binary: binary, version: p.version}
+}
+
+func (p *Parser) switchVersion(counter *Counter) error {
+	version, err := counter.GenusVersion()
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(cesrgo.MAJORS, version.Major) {
+		return fmt.Errorf("unsupported genus version: %d.%d", version.Major, version.Minor)
+	}
+
+	p.version = version
+
+	return nil
+}
+
+func (m *Message) merge(other *Message) {
+	m.ControllerIdxSigs = append(m.ControllerIdxSigs, other.ControllerIdxSigs...)
+	m.WitnessIdxSigs = append(m.WitnessIdxSigs, other.WitnessIdxSigs...)
+	m.NonTransReceiptCouples = append(m.NonTransReceiptCouples, other.NonTransReceiptCouples...)
+	m.TransReceiptQuadruples = append(m.TransReceiptQuadruples, other.TransReceiptQuadruples...)
+	m.TransIdxSigGroups = append(m.TransIdxSigGroups, other.TransIdxSigGroups...)
+	m.TransLastIdxSigGroups = append(m.TransLastIdxSigGroups, other.TransLastIdxSigGroups...)
+	m.FirstSeenReplayCouples = append(m.FirstSeenReplayCouples, other.FirstSeenReplayCouples...)
+	m.SealSourceCouples = append(m.SealSourceCouples, other.SealSourceCouples...)
+	m.SealSourceTriples = append(m.SealSourceTriples, other.SealSourceTriples...)
+	m.PathedMaterialGroups = append(m.PathedMaterialGroups, other.PathedMaterialGroups...)
+}
+
+type qualified interface {
+	Qb2() (types.Qb2, error)
+	Qb64b() (types.Qb64b, error)
+}
+
+// extractor walks a slice of a stream in either the text or the binary domain
+type extractor struct {
+	ims     []byte
+	binary  bool
+	version types.Version
+}
+
+func (e *extractor) consume(q qualified) error {
+	var n int
+	if e.binary {
+		qb2, err := q.Qb2()
+		if err != nil {
+			return err
+		}
+
+		n = len(qb2)
+	} else {
+		qb64b, err := q.Qb64b()
+		if err != nil {
+			return err
+		}
+
+		n = len(qb64b)
+	}
+
+	if n > len(e.ims) {
+		return common.NewShortageError(n - len(e.ims))
+	}
+
+	e.ims = e.ims[n:]
+
+	return nil
+}
+
+func (e *extractor) matterOption() mopts.MatterOption {
+	if e.binary {
+		return mopts.WithQb2(types.Qb2(e.ims))
+	}
+
+	return mopts.WithQb64(types.Qb64(e.ims))
+}
+
+func (e *extractor) indexerOption() iopts.IndexerOption {
+	if e.binary {
+		return iopts.WithQb2(types.Qb2(e.ims))
+	}
+
+	return iopts.WithQb64(types.Qb64(e.ims))
+}
+
+func (e *extractor) group(count types.Count) (*extractor, error) {
+	size := int(count) * 4
+	if e.binary {
+		size = int(count) * 3
+	}
+
+	if len(e.ims) < size {
+		return nil, common.NewShortageError(size - len(e.ims))
+	}
+
+	group := &extractor{ims: e.ims[:size], binary: e.binary, version: e.version}
+	e.ims = e.ims[size:]
+
+	return group, nil
+}
+
+func (e *extractor) counter() (*Counter, error) {
+	var opt options.CounterOption
+	if e.binary {
+		opt = options.WithQb2(types.Qb2(e.ims))
+	} else {
+		opt = options.WithQb64(types.Qb64(e.ims))
+	}
+
+	version := e.version
+	if version.Major == 0 {
+		version = cesrgo.VERSION
+	}
+
+	c, err := NewCounter(opt, options.WithVersion(version))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (e *extractor) siger() (*Siger, error) {
+	s, err := NewSiger(nil, e.indexerOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (e *extractor) sigers() ([]*Siger, error) {
+	sigers := []*Siger{}
+	for len(e.ims) > 0 {
+		siger, err := e.siger()
+		if err != nil {
+			return nil, err
+		}
+
+		sigers = append(sigers, siger)
+	}
+
+	return sigers, nil
+}
+
+func (e *extractor) verfer() (*Verfer, error) {
+	v, err := NewVerfer(e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (e *extractor) cigar(verfer *Verfer) (*Cigar, error) {
+	c, err := NewCigar(verfer, e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (e *extractor) prefixer() (*Prefixer, error) {
+	p, err := NewPrefixer(e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (e *extractor) seqner() (*Seqner, error) {
+	s, err := NewSeqner(nil, nil, e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (e *extractor) saider() (*Saider, error) {
+	s, err := NewSaider(nil, nil, nil, e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (e *extractor) dater() (*Dater, error) {
+	d, err := NewDater(nil, e.matterOption())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.consume(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (e *extractor) controllerIdxSigs() ([]*Siger, error) {
+	counter, err := e.counter()
+	if err != nil {
+		return nil, err
+	}
+
+	code := counter.GetCode()
+	if e.version.Major == cesrgo.VERSION_1_0.Major {
+		if code != one.ControllerIdxSigs {
+			return nil, fmt.Errorf("expected controller indexed signatures, got code: %s", code)
+		}
+
+		sigers := []*Siger{}
+		for range counter.GetCount() {
+			siger, err := e.siger()
+			if err != nil {
+				return nil, err
+			}
+
+			sigers = append(sigers, siger)
+		}
+
+		return sigers, nil
+	}
+
+	if code != two.ControllerIdxSigs && code != two.BigControllerIdxSigs {
+		return nil, fmt.Errorf("expected controller indexed signatures, got code: %s", code)
+	}
+
+	group, err := e.group(counter.GetCount())
+	if err != nil {
+		return nil, err
+	}
+
+	return group.sigers()
+}
+
+// attachments decodes a group with the given code and count, interpreting the count by the extractor's
+// genus version
+func (e *extractor) attachments(msg *Message, code types.Code, count types.Count) error {
+	if e.version.Major == cesrgo.VERSION_1_0.Major {
+		return e.attachments1(msg, code, count)
+	}
+
+	group, err := e.group(count)
+	if err != nil {
+		return err
+	}
+
+	switch code {
+	case two.AttachmentGroup, two.BigAttachmentGroup:
+		err = group.nested(msg)
+	case two.PathedMaterialGroup, two.BigPathedMaterialGroup:
+		msg.PathedMaterialGroups = append(msg.PathedMaterialGroups, types.Raw(slices.Clone(group.ims)))
+	default:
+		err = group.items(msg, code, func(int) bool { return len(group.ims) > 0 })
+	}
+
+	return malformed(code, err)
+}
+
+func (e *extractor) attachments1(msg *Message, code types.Code, count types.Count) error {
+	switch code {
+	case one.AttachmentGroup, one.BigAttachmentGroup:
+		group, err := e.group(count)
+		if err != nil {
+			return err
+		}
+
+		return malformed(code, group.nested(msg))
+	case one.PathedMaterialGroup, one.BigPathedMaterialGroup:
+		group, err := e.group(count)
+		if err != nil {
+			return err
+		}
+
+		msg.PathedMaterialGroups = append(msg.PathedMaterialGroups, types.Raw(slices.Clone(group.ims)))
+
+		return nil
+	default:
+		equivalent, ok := itemCodes1[code]
+		if !ok {
+			return fmt.Errorf("unsupported attachment group code: %s", code)
+		}
+
+		// the extent of an item counted group is unknown until parsed, so a shortage here may resolve
+		return e.items(msg, equivalent, func(i int) bool { return i < int(count) })
+	}
+}
+
+// malformed reports a shortage within a group whose extent was already available as an error, since more
+// input cannot resolve it
+func malformed(code types.Code, err error) error {
+	var shortage *common.ShortageError
+	if errors.As(err, &shortage) {
+		return fmt.Errorf("truncated material within group: code = %s", code)
+	}
+
+	return err
+}
+
+func (group *extractor) nested(msg *Message) error {
+	for len(group.ims) > 0 {
+		inner, err := group.counter()
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(attachmentGroupCodes[group.version.Major], inner.GetCode()) {
+			return fmt.Errorf("unexpected code in attachment group: %s", inner.GetCode())
+		}
+
+		if err := group.attachments(msg, inner.GetCode(), inner.GetCount()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *extractor) countedSigers(more func(int) bool) ([]*Siger, error) {
+	sigers := []*Siger{}
+	for i := 0; more(i); i++ {
+		siger, err := e.siger()
+		if err != nil {
+			return nil, err
+		}
+
+		sigers = append(sigers, siger)
+	}
+
+	return sigers, nil
+}
+
+// items decodes attachment items of a v2 group code for as long as more reports there are items left
+//
+//nolint:gocognit
+func (group *extractor) items(msg *Message, code types.Code, more func(int) bool) error {
+	switch code {
+	case two.ControllerIdxSigs, two.BigControllerIdxSigs:
+		sigers, err := group.countedSigers(more)
+		if err != nil {
+			return err
+		}
+
+		msg.ControllerIdxSigs = append(msg.ControllerIdxSigs, sigers...)
+	case two.WitnessIdxSigs, two.BigWitnessIdxSigs:
+		sigers, err := group.countedSigers(more)
+		if err != nil {
+			return err
+		}
+
+		msg.WitnessIdxSigs = append(msg.WitnessIdxSigs, sigers...)
+	case two.NonTransReceiptCouples, two.BigNonTransReceiptCouples:
+		for i := 0; more(i); i++ {
+			verfer, err := group.verfer()
+			if err != nil {
+				return err
+			}
+
+			cigar, err := group.cigar(verfer)
+			if err != nil {
+				return err
+			}
+
+			msg.NonTransReceiptCouples = append(msg.NonTransReceiptCouples, NonTransReceiptCouple{
+				Verfer: verfer,
+				Cigar:  cigar,
+			})
+		}
+	case two.TransReceiptQuadruples, two.BigTransReceiptQuadruples:
+		for i := 0; more(i); i++ {
+			prefixer, err := group.prefixer()
+			if err != nil {
+				return err
+			}
+
+			seqner, err := group.seqner()
+			if err != nil {
+				return err
+			}
+
+			saider, err := group.saider()
+			if err != nil {
+				return err
+			}
+
+			siger, err := group.siger()
+			if err != nil {
+				return err
+			}
+
+			msg.TransReceiptQuadruples = append(msg.TransReceiptQuadruples, TransReceiptQuadruple{
+				Prefixer: prefixer,
+				Seqner:   seqner,
+				Saider:   saider,
+				Siger:    siger,
+			})
+		}
+	case two.FirstSeenReplayCouples, two.BigFirstSeenReplayCouples:
+		for i := 0; more(i); i++ {
+			seqner, err := group.seqner()
+			if err != nil {
+				return err
+			}
+
+			dater, err := group.dater()
+			if err != nil {
+				return err
+			}
+
+			msg.FirstSeenReplayCouples = append(msg.FirstSeenReplayCouples, FirstSeenReplayCouple{
+				Seqner: seqner,
+				Dater:  dater,
+			})
+		}
+	case two.TransIdxSigGroups, two.BigTransIdxSigGroups:
+		for i := 0; more(i); i++ {
+			prefixer, err := group.prefixer()
+			if err != nil {
+				return err
+			}
+
+			seqner, err := group.seqner()
+			if err != nil {
+				return err
+			}
+
+			saider, err := group.saider()
+			if err != nil {
+				return err
+			}
+
+			sigers, err := group.controllerIdxSigs()
+			if err != nil {
+				return err
+			}
+
+			msg.TransIdxSigGroups = append(msg.TransIdxSigGroups, TransIdxSigGroup{
+				Prefixer: prefixer,
+				Seqner:   seqner,
+				Saider:   saider,
+				Sigers:   sigers,
+			})
+		}
+	case two.TransLastIdxSigGroups, two.BigTransLastIdxSigGroups:
+		for i := 0; more(i); i++ {
+			prefixer, err := group.prefixer()
+			if err != nil {
+				return err
+			}
+
+			sigers, err := group.controllerIdxSigs()
+			if err != nil {
+				return err
+			}
+
+			msg.TransLastIdxSigGroups = append(msg.TransLastIdxSigGroups, TransLastIdxSigGroup{
+				Prefixer: prefixer,
+				Sigers:   sigers,
+			})
+		}
+	case two.SealSourceCouples, two.BigSealSourceCouples:
+		for i := 0; more(i); i++ {
+			seqner, err := group.seqner()
+			if err != nil {
+				return err
+			}
+
+			saider, err := group.saider()
+			if err != nil {
+				return err
+			}
+
+			msg.SealSourceCouples = append(msg.SealSourceCouples, SealSourceCouple{
+				Seqner: seqner,
+				Saider: saider,
+			})
+		}
+	case two.SealSourceTriples, two.BigSealSourceTriples:
+		for i := 0; more(i); i++ {
+			prefixer, err := group.prefixer()
+			if err != nil {
+				return err
+			}
+
+			seqner, err := group.seqner()
+			if err != nil {
+				return err
+			}
+
+			saider, err := group.saider()
+			if err != nil {
+				return err
+			}
+
+			msg.SealSourceTriples = append(msg.SealSourceTriples, SealSourceTriple{
+				Prefixer: prefixer,
+				Seqner:   seqner,
+				Saider:   saider,
+			})
+		}
+	default:
+		return fmt.Errorf("unsupported attachment group code: %s", code)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package cesr
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/jasoncolburne/cesrgo"
@@ -107,16 +108,39 @@ func (s *sad) SetRaw(raw types.Raw) {
 }
 
 func (s *Sadder) inhale(raw types.Raw) error {
-	proto, pvrsn, kind, size, _, err := common.Smell(raw)
-	if err != nil {
-		return err
+	var (
+		proto types.Proto
+		pvrsn types.Version
+		kind  types.Kind
+		size  types.Size
+		ked   types.Map
+		err   error
+	)
+
+	if len(raw) > 0 && raw[0] == '-' {
+		// native cesr bodies begin with a group counter rather than a version string
+		proto, pvrsn, size, _, err = SmellNative(raw)
+		if err != nil {
+			return err
+		}
+
+		kind = cesrgo.Kind_CESR
+	} else {
+		proto, pvrsn, kind, size, _, err = common.Smell(raw)
+		if err != nil {
+			return err
+		}
 	}
 
-	if pvrsn.Major != cesrgo.VERSION.Major && pvrsn.Minor != cesrgo.VERSION.Minor {
-		return fmt.Errorf("version mismatch")
+	if !slices.Contains(cesrgo.MAJORS, pvrsn.Major) {
+		return fmt.Errorf("unsupported version: %d.%d", pvrsn.Major, pvrsn.Minor)
 	}
 
-	ked, err := common.Unmarshal(kind, raw)
+	if kind == cesrgo.Kind_CESR {
+		ked, err = UnmarshalNative(raw)
+	} else {
+		ked, err = common.Unmarshal(kind, raw)
+	}
 	if err != nil {
 		return err
 	}
@@ -165,7 +189,35 @@ func (s *Sadder) exhale(ked types.Map, kind *types.Kind) (
 	types.Version,
 	error,
 ) {
-	return common.Sizeify(ked, kind, nil)
+	return sizeify(ked, kind)
+}
+
+// sizeify serializes ked as kind, sizing its version string. A message is sized at the version it
+// declares, which Sizeify checks against the supported ones.
+//
+//nolint:gocritic
+func sizeify(ked types.Map, kind *types.Kind) (
+	types.Raw,
+	types.Proto,
+	types.Kind,
+	types.Map,
+	types.Version,
+	error,
+) {
+	if kind != nil && *kind == cesrgo.Kind_CESR {
+		return sizeifyNative(ked)
+	}
+
+	var version *types.Version
+	if v, ok := ked.Get("v"); ok {
+		if vs, ok := v.(string); ok {
+			if _, pvrsn, _, _, _, err := common.Deversify(vs); err == nil {
+				version = &pvrsn
+			}
+		}
+	}
+
+	return common.Sizeify(ked, kind, version)
 }
 
 func NewSadder(
@@ -174,6 +226,22 @@ func NewSadder(
 	ked *types.Map,
 	kind *types.Kind,
 	saidify bool,
+) (*Sadder, error) {
+	var labels []string
+	if saidify {
+		labels = []string{"d"}
+	}
+
+	return newSadder(code, raw, ked, kind, labels)
+}
+
+// newSadder saidifies or verifies each of labels, which all share the same SAID
+func newSadder(
+	code *types.Code,
+	raw *types.Raw,
+	ked *types.Map,
+	kind *types.Kind,
+	labels []string,
 ) (*Sadder, error) {
 	if code == nil {
 		codeStr := codex.Blake3_256
@@ -202,10 +270,10 @@ func NewSadder(
 		}
 
 		kedCopy := ked.Clone()
-		if saidify {
-			_, ok := kedCopy.Set("d", strings.Repeat("#", int(*szg.Fs)))
+		for _, label := range labels {
+			_, ok := kedCopy.Set(label, strings.Repeat("#", int(*szg.Fs)))
 			if !ok {
-				return nil, fmt.Errorf("failed to set d")
+				return nil, fmt.Errorf("failed to set %s", label)
 			}
 		}
 
@@ -227,37 +295,97 @@ func NewSadder(
 		return nil, fmt.Errorf("raw or ked must be provided")
 	}
 
-	if saidify {
-		saider, err := NewSaider(&s.ked, nil, nil, options.WithCode(*code))
+	if len(labels) > 0 {
+		var err error
+		if s.saider != nil {
+			err = s.verify(labels)
+		} else {
+			err = s.saidify(*code, labels)
+		}
+
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		if s.saider != nil {
-			parsedQb64, err := s.saider.Qb64()
-			if err != nil {
-				return nil, err
-			}
+	return s, nil
+}
 
-			derivedQb64, err := saider.Qb64()
-			if err != nil {
-				return nil, err
-			}
+// verify checks that each of labels holds the SAID of the serialization
+func (s *Sadder) verify(labels []string) error {
+	saider, err := s.derive(s.saider.GetCode(), labels)
+	if err != nil {
+		return err
+	}
 
-			if parsedQb64 != derivedQb64 {
-				return nil, fmt.Errorf("saider mismatch")
-			}
-		} else {
-			s.saider = saider
+	parsedQb64, err := s.saider.Qb64()
+	if err != nil {
+		return err
+	}
 
-			ked := s.GetKed()
-			qb64, err := saider.Qb64()
-			if err != nil {
-				return nil, err
-			}
-			ked.Set("d", qb64)
+	derivedQb64, err := saider.Qb64()
+	if err != nil {
+		return err
+	}
+
+	if parsedQb64 != derivedQb64 {
+		return fmt.Errorf("saider mismatch")
+	}
+
+	for _, label := range labels[1:] {
+		value, _ := s.ked.Get(label)
+		if value != string(derivedQb64) {
+			return fmt.Errorf("saider mismatch: label = %s", label)
 		}
 	}
 
-	return s, nil
+	return nil
+}
+
+// saidify computes the SAID and places it in each of labels
+func (s *Sadder) saidify(code types.Code, labels []string) error {
+	saider, err := s.derive(code, labels)
+	if err != nil {
+		return err
+	}
+
+	s.saider = saider
+
+	ked := s.GetKed()
+	qb64, err := saider.Qb64()
+	if err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		ked.Set(label, string(qb64))
+	}
+
+	// the raw was serialized with the dummy, and must carry the SAID to verify when loaded
+	kind := s.GetKind()
+	raw, _, _, _, _, err := s.exhale(ked, &kind)
+	if err != nil {
+		return err
+	}
+
+	s.SetRaw(raw)
+
+	return nil
+}
+
+// derive computes the SAID of the serialization with each of labels replaced by a dummy
+func (s *Sadder) derive(code types.Code, labels []string) (*Saider, error) {
+	ked := s.GetKed()
+	kind := s.GetKind()
+
+	digest, _, err := derive(&ked, &code, &kind, labels, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSaider(nil, nil, nil, options.WithCode(code), options.WithRaw(digest))
+}
+
+func (s *Sadder) GetSaider() *Saider {
+	return s.saider
 }
@@ -80,7 +80,7 @@ func NewSaider(
 			return nil, fmt.Errorf("unexpected code: %s", s.code)
 		}
 
-		rawValue, _, err := derive(sad, code, kind, label, []string{})
+		rawValue, _, err := derive(sad, code, kind, []string{*label}, []string{})
 		if err != nil {
 			return nil, err
 		}
@@ -97,15 +97,17 @@ func NewSaider(
 	return s, nil
 }
 
-func derive(sad *types.Map, code *types.Code, kind *types.Kind, label *string, ignore []string) (types.Raw, types.Map, error) {
+// derive computes the digest of sad, serialized as kind with each of labels, "d" by default, replaced by a
+// dummy the size of code and the fields in ignore removed. A sad with a version string is sized first, so
+// the digest covers the size of the serialization. The serialized sad is returned with the digest.
+func derive(sad *types.Map, code *types.Code, kind *types.Kind, labels []string, ignore []string) (types.Raw, types.Map, error) {
 	if code == nil {
 		codeBlake3 := codex.Blake3_256
 		code = &codeBlake3
 	}
 
-	if label == nil {
-		labelStr := "d"
-		label = &labelStr
+	if len(labels) == 0 {
+		labels = []string{"d"}
 	}
 
 	if kind == nil {
@@ -117,12 +119,6 @@ func derive(sad *types.Map, code *types.Code, kind *types.Kind, label *string, i
 		return nil, types.Map{}, fmt.Errorf("unexpected code: %s", *code)
 	}
 
-	sadCopy := sad.Clone()
-	_, ok := sadCopy.Get(*label)
-	if !ok {
-		return nil, types.Map{}, fmt.Errorf("label not found: %s", *label)
-	}
-
 	szg, ok := codex.Sizes[*code]
 	if !ok {
 		return nil, types.Map{}, fmt.Errorf("unknown code: %s", *code)
@@ -132,10 +128,16 @@ func derive(sad *types.Map, code *types.Code, kind *types.Kind, label *string, i
 		return nil, types.Map{}, fmt.Errorf("programmer error: sizage fs is nil")
 	}
 
+	sadCopy := sad.Clone()
 	dummy := strings.Repeat("#", int(*szg.Fs))
-	_, ok = sadCopy.Set(*label, dummy)
-	if !ok {
-		return nil, types.Map{}, fmt.Errorf("failed to set dummy")
+	for _, label := range labels {
+		if _, ok := sadCopy.Get(label); !ok {
+			return nil, types.Map{}, fmt.Errorf("label not found: %s", label)
+		}
+
+		if _, ok := sadCopy.Set(label, dummy); !ok {
+			return nil, types.Map{}, fmt.Errorf("failed to set dummy")
+		}
 	}
 
 	for _, key := range ignore {
@@ -145,7 +147,17 @@ func derive(sad *types.Map, code *types.Code, kind *types.Kind, label *string, i
 		}
 	}
 
-	cpa, err := common.Marshal(sadCopy, kind)
+	var (
+		cpa types.Raw
+		err error
+	)
+	if _, ok := sadCopy.Get("v"); ok {
+		cpa, _, _, sadCopy, _, err = sizeify(sadCopy, kind)
+	} else if *kind == cesrgo.Kind_CESR {
+		cpa, err = MarshalNative(sadCopy)
+	} else {
+		cpa, err = common.Marshal(sadCopy, kind)
+	}
 	if err != nil {
 		return nil, types.Map{}, fmt.Errorf("failed to marshal: %w", err)
 	}
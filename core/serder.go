@@ -0,0 +1,482 @@
+package cesr
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/jasoncolburne/cesrgo"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// KERIFields lists the fields of each key event, in order, by protocol major version
+var KERIFields = map[uint32]map[types.Ilk][]string{
+	cesrgo.VERSION_1_0.Major: {
+		cesrgo.Ilk_ICP: {"v", "t", "d", "i", "s", "kt", "k", "nt", "n", "bt", "b", "c", "a"},
+		cesrgo.Ilk_ROT: {"v", "t", "d", "i", "s", "p", "kt", "k", "nt", "n", "bt", "br", "ba", "a"},
+		cesrgo.Ilk_IXN: {"v", "t", "d", "i", "s", "p", "a"},
+		cesrgo.Ilk_DIP: {"v", "t", "d", "i", "s", "kt", "k", "nt", "n", "bt", "b", "c", "a", "di"},
+		cesrgo.Ilk_DRT: {"v", "t", "d", "i", "s", "p", "kt", "k", "nt", "n", "bt", "br", "ba", "a"},
+		cesrgo.Ilk_RCT: {"v", "t", "d", "i", "s"},
+	},
+	cesrgo.VERSION_2_0.Major: {
+		cesrgo.Ilk_ICP: {"v", "t", "d", "i", "s", "kt", "k", "nt", "n", "bt", "b", "c", "a"},
+		cesrgo.Ilk_ROT: {"v", "t", "d", "i", "s", "p", "kt", "k", "nt", "n", "bt", "br", "ba", "c", "a"},
+		cesrgo.Ilk_IXN: {"v", "t", "d", "i", "s", "p", "a"},
+		cesrgo.Ilk_DIP: {"v", "t", "d", "i", "s", "kt", "k", "nt", "n", "bt", "b", "c", "a", "di"},
+		cesrgo.Ilk_DRT: {"v", "t", "d", "i", "s", "p", "kt", "k", "nt", "n", "bt", "br", "ba", "c", "a"},
+		cesrgo.Ilk_RCT: {"v", "t", "d", "i", "s"},
+	},
+}
+
+// establishment event ilks
+var ESTIVES = []types.Ilk{
+	cesrgo.Ilk_ICP,
+	cesrgo.Ilk_ROT,
+	cesrgo.Ilk_DIP,
+	cesrgo.Ilk_DRT,
+}
+
+// SerderKERI is a Sadder for KERI key events. Its fields are validated against the layout of the event's
+// ilk and protocol version, and are available as primitives.
+type SerderKERI struct {
+	Sadder
+}
+
+// NewSerderKERI loads a key event from raw or ked. When saidify is set, the SAID is computed (ked) or
+// verified (raw); the prefix of an inception is included when it is self-addressing.
+func NewSerderKERI(
+	code *types.Code,
+	raw *types.Raw,
+	ked *types.Map,
+	kind *types.Kind,
+	saidify bool,
+) (*SerderKERI, error) {
+	var (
+		sadder *Sadder
+		err    error
+	)
+
+	if raw != nil {
+		sadder, err = newSadder(code, raw, ked, kind, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if labels := keriSaidive(sadder.GetKed(), false); saidify && len(labels) > 0 {
+			if err := sadder.verify(labels); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var labels []string
+		if saidify && ked != nil {
+			labels = keriSaidive(*ked, true)
+		}
+
+		sadder, err = newSadder(code, raw, ked, kind, labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s := &SerderKERI{Sadder: *sadder}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// keriSaidive lists the fields holding the SAID. An inception's prefix is included when it is
+// self-addressing: when creating, the prefix is left empty; when loading, it equals the SAID. A receipt
+// has no SAID of its own, its d is that of the receipted event.
+func keriSaidive(ked types.Map, creating bool) []string {
+	ilk, _ := ked.Get("t")
+	if ilk == string(cesrgo.Ilk_RCT) {
+		return nil
+	}
+
+	labels := []string{"d"}
+	if ilk != string(cesrgo.Ilk_ICP) && ilk != string(cesrgo.Ilk_DIP) {
+		return labels
+	}
+
+	i, _ := ked.Get("i")
+	d, _ := ked.Get("d")
+
+	if (creating && i == "") || (!creating && i == d) {
+		labels = append(labels, "i")
+	}
+
+	return labels
+}
+
+func (s *SerderKERI) validate() error {
+	if s.GetProto() != cesrgo.Proto_KERI {
+		return fmt.Errorf("unexpected protocol for key event: %s", s.GetProto())
+	}
+
+	fieldsByIlk, ok := KERIFields[s.GetVersion().Major]
+	if !ok {
+		return fmt.Errorf("unsupported version: %d.%d", s.GetVersion().Major, s.GetVersion().Minor)
+	}
+
+	ilk, err := s.stringField("t")
+	if err != nil {
+		return err
+	}
+
+	fields, ok := fieldsByIlk[types.Ilk(ilk)]
+	if !ok {
+		return fmt.Errorf("unsupported ilk: %s", ilk)
+	}
+
+	keys := s.GetKed().Keys()
+	if !slices.Equal(keys, fields) {
+		return fmt.Errorf("invalid fields for %s: expected %v, got %v", ilk, fields, keys)
+	}
+
+	return nil
+}
+
+func (s *SerderKERI) field(label string) (any, error) {
+	value, ok := s.ked.Get(label)
+	if !ok {
+		return nil, fmt.Errorf("field not present: %s", label)
+	}
+
+	return value, nil
+}
+
+func (s *SerderKERI) stringField(label string) (string, error) {
+	value, err := s.field(label)
+	if err != nil {
+		return "", err
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("field %s is not a string: %T", label, value)
+	}
+
+	return str, nil
+}
+
+func (s *SerderKERI) stringsField(label string) ([]string, error) {
+	value, err := s.field(label)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case types.List:
+		return stringsOf(label, v)
+	case []any:
+		return stringsOf(label, v)
+	default:
+		return nil, fmt.Errorf("field %s is not a list: %T", label, value)
+	}
+}
+
+func (s *SerderKERI) qb64sField(label string) ([]types.Qb64, error) {
+	strs, err := s.stringsField(label)
+	if err != nil {
+		return nil, err
+	}
+
+	qb64s := make([]types.Qb64, len(strs))
+	for i, str := range strs {
+		qb64s[i] = types.Qb64(str)
+	}
+
+	return qb64s, nil
+}
+
+func stringsOf(label string, values []any) ([]string, error) {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("field %s has a non string member: %T", label, value)
+		}
+
+		strs[i] = str
+	}
+
+	return strs, nil
+}
+
+func (s *SerderKERI) Ilk() types.Ilk {
+	ilk, _ := s.stringField("t")
+	return types.Ilk(ilk)
+}
+
+func (s *SerderKERI) Said() string {
+	said, _ := s.stringField("d")
+	return said
+}
+
+func (s *SerderKERI) Pre() string {
+	pre, _ := s.stringField("i")
+	return pre
+}
+
+// Estive reports whether the event is an establishment event
+func (s *SerderKERI) Estive() bool {
+	return slices.Contains(ESTIVES, s.Ilk())
+}
+
+func (s *SerderKERI) Prefixer() (*Prefixer, error) {
+	pre, err := s.stringField("i")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrefixer(options.WithQb64(types.Qb64(pre)))
+}
+
+func (s *SerderKERI) Number() (*Number, error) {
+	snh, err := s.stringField("s")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNumber(nil, &snh)
+}
+
+func (s *SerderKERI) Seqner() (*Seqner, error) {
+	number, err := s.Number()
+	if err != nil {
+		return nil, err
+	}
+
+	sn := number.Number()
+
+	return NewSeqner(&sn, nil)
+}
+
+func (s *SerderKERI) Sn() (*big.Int, error) {
+	number, err := s.Number()
+	if err != nil {
+		return nil, err
+	}
+
+	sn := number.Number()
+
+	return &sn, nil
+}
+
+// Tholder returns the signing threshold, kt
+func (s *SerderKERI) Tholder() (*Tholder, error) {
+	return s.tholder("kt")
+}
+
+// NextTholder returns the threshold for the next key set, nt
+func (s *SerderKERI) NextTholder() (*Tholder, error) {
+	return s.tholder("nt")
+}
+
+func (s *SerderKERI) tholder(label string) (*Tholder, error) {
+	sith, err := s.field(label)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTholder(nil, nil, sithify(sith))
+}
+
+// sithify converts decoded weighted thresholds to the forms Tholder accepts
+func sithify(sith any) any {
+	switch v := sith.(type) {
+	case types.Map:
+		m := map[string]any{}
+		for _, key := range v.Keys() {
+			value, _ := v.Get(key)
+			m[key] = sithify(value)
+		}
+
+		return m
+	case types.List:
+		return sithify([]any(v))
+	case []string:
+		l := make([]any, len(v))
+		for i, value := range v {
+			l[i] = value
+		}
+
+		return l
+	case []any:
+		l := make([]any, len(v))
+		for i, value := range v {
+			l[i] = sithify(value)
+		}
+
+		return l
+	default:
+		return sith
+	}
+}
+
+// BackerNumber returns the backer (witness) threshold, bt
+func (s *SerderKERI) BackerNumber() (*Number, error) {
+	bt, err := s.stringField("bt")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNumber(nil, &bt)
+}
+
+func (s *SerderKERI) Verfers() ([]*Verfer, error) {
+	keys, err := s.stringsField("k")
+	if err != nil {
+		return nil, err
+	}
+
+	verfers := make([]*Verfer, len(keys))
+	for i, key := range keys {
+		verfer, err := NewVerfer(options.WithQb64(types.Qb64(key)))
+		if err != nil {
+			return nil, err
+		}
+
+		verfers[i] = verfer
+	}
+
+	return verfers, nil
+}
+
+// NextDigers returns the digests of the next key set, n
+func (s *SerderKERI) NextDigers() ([]*Diger, error) {
+	digs, err := s.stringsField("n")
+	if err != nil {
+		return nil, err
+	}
+
+	digers := make([]*Diger, len(digs))
+	for i, dig := range digs {
+		diger, err := NewDiger(nil, options.WithQb64(types.Qb64(dig)))
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(codex.DigCodex, diger.GetCode()) {
+			return nil, fmt.Errorf("invalid next key digest code: %s", diger.GetCode())
+		}
+
+		digers[i] = diger
+	}
+
+	return digers, nil
+}
+
+// Backers returns the backers (witnesses) of an inception, b
+func (s *SerderKERI) Backers() ([]*Prefixer, error) {
+	return s.prefixers("b")
+}
+
+// Cuts returns the backers removed by a rotation, br
+func (s *SerderKERI) Cuts() ([]*Prefixer, error) {
+	return s.prefixers("br")
+}
+
+// Adds returns the backers added by a rotation, ba
+func (s *SerderKERI) Adds() ([]*Prefixer, error) {
+	return s.prefixers("ba")
+}
+
+func (s *SerderKERI) prefixers(label string) ([]*Prefixer, error) {
+	pres, err := s.stringsField(label)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixers := make([]*Prefixer, len(pres))
+	for i, pre := range pres {
+		prefixer, err := NewPrefixer(options.WithQb64(types.Qb64(pre)))
+		if err != nil {
+			return nil, err
+		}
+
+		prefixers[i] = prefixer
+	}
+
+	return prefixers, nil
+}
+
+// Traitors returns the configuration traits, c
+func (s *SerderKERI) Traitors() ([]*Traitor, error) {
+	traits, err := s.stringsField("c")
+	if err != nil {
+		return nil, err
+	}
+
+	traitors := make([]*Traitor, len(traits))
+	for i, trait := range traits {
+		traitor, err := NewTraitor((*types.Trait)(&trait))
+		if err != nil {
+			return nil, err
+		}
+
+		traitors[i] = traitor
+	}
+
+	return traitors, nil
+}
+
+// Prior returns the digest of the prior event, p
+func (s *SerderKERI) Prior() (*Diger, error) {
+	p, err := s.stringField("p")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDiger(nil, options.WithQb64(types.Qb64(p)))
+}
+
+// Delegator returns the delegator of a delegated inception, di
+func (s *SerderKERI) Delegator() (*Prefixer, error) {
+	di, err := s.stringField("di")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrefixer(options.WithQb64(types.Qb64(di)))
+}
+
+// Seals returns the anchored seals, a
+func (s *SerderKERI) Seals() ([]types.Map, error) {
+	value, err := s.field("a")
+	if err != nil {
+		return nil, err
+	}
+
+	var list []any
+	switch v := value.(type) {
+	case []any:
+		list = v
+	case types.List:
+		list = v
+	case []types.Map:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("field a is not a list: %T", value)
+	}
+
+	seals := make([]types.Map, len(list))
+	for i, element := range list {
+		seal, ok := element.(types.Map)
+		if !ok {
+			return nil, fmt.Errorf("seal is not a map: %T", element)
+		}
+
+		seals[i] = seal
+	}
+
+	return seals, nil
+}
@@ -121,6 +121,8 @@ func (s *Signer) SignUnindexed(ser []byte) (*Cigar, error) {
 		code = mdex.ECDSA_256k1_Sig
 	case mdex.ECDSA_256r1_Seed:
 		code = mdex.ECDSA_256r1_Sig
+	case mdex.Ed448_Seed:
+		code = mdex.Ed448_Sig
 	default:
 		return nil, fmt.Errorf("unexpected code: %s", s.code)
 	}
@@ -163,6 +165,8 @@ func (s *Signer) SignIndexed(
 				code = idex.ECDSA_256k1_Crt
 			case mdex.ECDSA_256r1_Seed:
 				code = idex.ECDSA_256r1_Crt
+			case mdex.Ed448_Seed:
+				code = idex.Ed448_Crt
 			default:
 				return nil, fmt.Errorf("unexpected code: %s", s.code)
 			}
@@ -174,6 +178,8 @@ func (s *Signer) SignIndexed(
 				code = idex.ECDSA_256k1_Big_Crt
 			case mdex.ECDSA_256r1_Seed:
 				code = idex.ECDSA_256r1_Big_Crt
+			case mdex.Ed448_Seed:
+				code = idex.Ed448_Big_Crt
 			default:
 				return nil, fmt.Errorf("unexpected code: %s", s.code)
 			}
@@ -194,6 +200,8 @@ func (s *Signer) SignIndexed(
 				code = idex.ECDSA_256k1
 			case mdex.ECDSA_256r1_Seed:
 				code = idex.ECDSA_256r1
+			case mdex.Ed448_Seed:
+				code = idex.Ed448
 			default:
 				return nil, fmt.Errorf("unexpected code: %s", s.code)
 			}
@@ -205,6 +213,8 @@ func (s *Signer) SignIndexed(
 				code = idex.ECDSA_256k1_Big
 			case mdex.ECDSA_256r1_Seed:
 				code = idex.ECDSA_256r1_Big
+			case mdex.Ed448_Seed:
+				code = idex.Ed448_Big
 			default:
 				return nil, fmt.Errorf("unexpected code: %s", s.code)
 			}
@@ -0,0 +1,365 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+const (
+	acdcTestIssuer   = "EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU"
+	acdcTestIssuee   = "EAd3fxxJlTsX-ewd8PBNoEbaTb2OfCBT7ZhBtK1Ao1OJ"
+	acdcTestRegistry = "EKAeKcFV9KSrlq3Cq0wc8u7pa5Q4GYlUQ5OeZLCl2DpN"
+	acdcTestChained  = "EEm1Q-2UqfWbGdrF1xmDpbeUeDfTnGDUfW8d7J9Zd9TR"
+)
+
+func acdcTestMap(t *testing.T, pairs ...any) types.Map {
+	t.Helper()
+
+	m := types.NewMap()
+	for i := 0; i < len(pairs); i += 2 {
+		label, ok := pairs[i].(string)
+		if !ok {
+			t.Fatalf("label is not a string: %v", pairs[i])
+		}
+
+		m.Set(label, pairs[i+1])
+	}
+
+	return m
+}
+
+func acdcTestVersion(t *testing.T, version types.Version, kind types.Kind) string {
+	t.Helper()
+
+	proto := cesrgo.Proto_ACDC
+
+	v, err := common.Versify(&proto, &version, &kind, 0, nil)
+	if err != nil {
+		t.Fatalf("failed to versify: %v", err)
+	}
+
+	return v
+}
+
+func acdcTestCredential(t *testing.T, kind types.Kind) types.Map {
+	t.Helper()
+
+	noncer, err := cesr.NewNoncer(nil)
+	if err != nil {
+		t.Fatalf("failed to create noncer: %v", err)
+	}
+
+	nonce, err := noncer.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	schema := acdcTestMap(t,
+		"$id", "",
+		"$schema", "http://json-schema.org/draft-07/schema#",
+		"type", "object",
+		// a property named d is not a block
+		"properties", acdcTestMap(t, "d", acdcTestMap(t, "type", "string")),
+	)
+
+	return acdcTestMap(t,
+		"v", acdcTestVersion(t, cesrgo.VERSION_1_0, kind),
+		"d", "",
+		"u", string(nonce),
+		"i", acdcTestIssuer,
+		"ri", acdcTestRegistry,
+		"s", schema,
+		"a", acdcTestMap(t,
+			"d", "",
+			"u", string(nonce),
+			"i", acdcTestIssuee,
+			"dt", "2025-01-01T00:00:00.000000+00:00",
+			"LEI", "254900OPPU84GM83MG36",
+		),
+		"e", acdcTestMap(t,
+			"d", "",
+			"qvi", acdcTestMap(t, "d", "", "n", acdcTestChained, "s", acdcTestRegistry),
+		),
+		"r", acdcTestMap(t,
+			"d", "",
+			"usageDisclaimer", acdcTestMap(t, "l", "Usage of this credential is at the holder's risk."),
+		),
+	)
+}
+
+func TestSerderACDCRoundTrip(t *testing.T) {
+	for _, kind := range []types.Kind{cesrgo.Kind_JSON, cesrgo.Kind_CBOR, cesrgo.Kind_MGPK} {
+		ked := acdcTestCredential(t, kind)
+
+		serder, err := cesr.NewSerderACDC(nil, nil, &ked, &kind, true)
+		if err != nil {
+			t.Fatalf("failed to create credential (%s): %v", kind, err)
+		}
+
+		raw := serder.GetRaw()
+
+		loaded, err := cesr.NewSerderACDC(nil, &raw, nil, nil, true)
+		if err != nil {
+			t.Fatalf("failed to verify credential (%s): %v", kind, err)
+		}
+
+		if loaded.Said() != serder.Said() || loaded.Ilk() != "" || loaded.Issuer() != acdcTestIssuer {
+			t.Fatalf("unexpected credential (%s): %s", kind, raw)
+		}
+
+		prefixer, err := loaded.Prefixer()
+		if qb64, _ := prefixer.Qb64(); err != nil || qb64 != acdcTestIssuer {
+			t.Fatalf("unexpected issuer: %s (%v)", qb64, err)
+		}
+
+		registry, err := loaded.Registry()
+		if qb64, _ := registry.Qb64(); err != nil || qb64 != acdcTestRegistry {
+			t.Fatalf("unexpected registry: %s (%v)", qb64, err)
+		}
+
+		if _, err := loaded.Noncer(); err != nil {
+			t.Fatalf("failed to get noncer: %v", err)
+		}
+
+		issuee, err := loaded.Issuee()
+		if qb64, _ := issuee.Qb64(); err != nil || qb64 != acdcTestIssuee {
+			t.Fatalf("unexpected issuee: %s (%v)", qb64, err)
+		}
+
+		schema, err := loaded.Schema()
+		if err != nil || schema.Block == nil {
+			t.Fatalf("expected expanded schema: %v", err)
+		}
+
+		properties, _ := schema.Block.Get("properties")
+		if d, _ := properties.(types.Map).Get("d"); d == nil {
+			t.Fatalf("expected schema properties to be untouched")
+		}
+
+		for _, section := range []func() (*cesr.ACDCSection, error){loaded.Attributes, loaded.Edges, loaded.Rules} {
+			block, err := section()
+			if err != nil || block.Block == nil {
+				t.Fatalf("expected expanded section: %v", err)
+			}
+
+			d, _ := block.Block.Get("d")
+			if qb64, _ := block.Saider.Qb64(); string(qb64) != d {
+				t.Fatalf("unexpected section said: %s", qb64)
+			}
+		}
+
+		// a flat block has the SAID a Saider derives over it
+		attributes, _ := loaded.Attributes()
+		saider, err := cesr.NewSaider(attributes.Block, nil, &kind)
+		if err != nil {
+			t.Fatalf("failed to create saider: %v", err)
+		}
+
+		if !bytes.Equal(saider.GetRaw(), attributes.Saider.GetRaw()) {
+			t.Fatalf("attribute said mismatch (%s)", kind)
+		}
+
+		// nested edge blocks are saidified too
+		edges, _ := loaded.Edges()
+		qvi, _ := edges.Block.Get("qvi")
+		if d, _ := qvi.(types.Map).Get("d"); d == "" {
+			t.Fatalf("expected nested edge said")
+		}
+	}
+}
+
+func TestSerderACDCNestedSaids(t *testing.T) {
+	ked := acdcTestCredential(t, cesrgo.Kind_JSON)
+
+	serder, err := cesr.NewSerderACDC(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create credential: %v", err)
+	}
+
+	// replace the attribute block's said, then recompute only the top level said
+	tampered := serder.GetKed().Clone()
+	attributes, _ := tampered.Get("a")
+	block := attributes.(types.Map).Clone()
+	block.Set("d", serder.Said())
+	tampered.Set("a", block)
+
+	sadder, err := cesr.NewSadder(nil, nil, &tampered, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	raw := sadder.GetRaw()
+	if _, err := cesr.NewSerderACDC(nil, &raw, nil, nil, false); err != nil {
+		t.Fatalf("failed to load without verification: %v", err)
+	}
+
+	if _, err := cesr.NewSerderACDC(nil, &raw, nil, nil, true); err == nil {
+		t.Fatalf("expected nested said mismatch to be rejected")
+	}
+}
+
+func TestSerderACDCCompactVersion2(t *testing.T) {
+	kind := cesrgo.Kind_JSON
+
+	ked := acdcTestMap(t,
+		"v", acdcTestVersion(t, cesrgo.VERSION_2_0, kind),
+		"t", string(cesrgo.Ilk_ACM),
+		"d", "",
+		"i", acdcTestIssuer,
+		"rd", acdcTestRegistry,
+		"s", acdcTestChained,
+		"a", acdcTestChained,
+	)
+
+	serder, err := cesr.NewSerderACDC(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create credential: %v", err)
+	}
+
+	if serder.Ilk() != cesrgo.Ilk_ACM || serder.GetVersion() != cesrgo.VERSION_2_0 {
+		t.Fatalf("unexpected credential: %s", serder.GetRaw())
+	}
+
+	attributes, err := serder.Attributes()
+	if err != nil || attributes.Block != nil {
+		t.Fatalf("expected compact attributes: %v", err)
+	}
+
+	if qb64, _ := attributes.Saider.Qb64(); qb64 != acdcTestChained {
+		t.Fatalf("unexpected attribute said: %s", qb64)
+	}
+
+	if _, err := serder.Noncer(); err == nil {
+		t.Fatalf("expected missing nonce to be reported")
+	}
+
+	if _, err := serder.Issuee(); err == nil {
+		t.Fatalf("expected compact attributes to have no issuee")
+	}
+
+	registry, err := serder.Registry()
+	if qb64, _ := registry.Qb64(); err != nil || qb64 != acdcTestRegistry {
+		t.Fatalf("unexpected registry: %s (%v)", qb64, err)
+	}
+}
+
+func TestSerderACDCInvalid(t *testing.T) {
+	kind := cesrgo.Kind_JSON
+	version := acdcTestVersion(t, cesrgo.VERSION_1_0, kind)
+
+	tests := []struct {
+		name string
+		ked  types.Map
+	}{
+		{"missing issuer", acdcTestMap(t, "v", version, "d", "", "s", acdcTestChained)},
+		{"out of order", acdcTestMap(t, "v", version, "d", "", "s", acdcTestChained, "i", acdcTestIssuer)},
+		{"unknown field", acdcTestMap(t, "v", version, "d", "", "i", acdcTestIssuer, "s", acdcTestChained, "x", "")},
+		{"attributes and aggregate", acdcTestMap(t, "v", version, "d", "", "i", acdcTestIssuer, "s", acdcTestChained, "a", acdcTestChained, "A", acdcTestChained)},
+		{"key event", acdcTestMap(t, "v", "KERI10JSON000000_", "d", "", "i", acdcTestIssuer, "s", acdcTestChained)},
+	}
+
+	for _, test := range tests {
+		if _, err := cesr.NewSerderACDC(nil, nil, &test.ked, &kind, true); err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestSerderACDCCompactAndExpand(t *testing.T) {
+	ked := acdcTestCredential(t, cesrgo.Kind_JSON)
+
+	serder, err := cesr.NewSerderACDC(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create credential: %v", err)
+	}
+
+	compact, err := serder.Compact()
+	if err != nil {
+		t.Fatalf("failed to compact: %v", err)
+	}
+
+	if compact.Said() != serder.Said() || len(compact.GetRaw()) >= len(serder.GetRaw()) {
+		t.Fatalf("unexpected compact form: %s", compact.GetRaw())
+	}
+
+	blocks := map[string]types.Map{}
+	for field, section := range map[string]func() (*cesr.ACDCSection, error){
+		"s": serder.Schema,
+		"a": serder.Attributes,
+		"e": serder.Edges,
+		"r": serder.Rules,
+	} {
+		expanded, err := section()
+		if err != nil {
+			t.Fatalf("failed to get section %s: %v", field, err)
+		}
+
+		value, _ := compact.GetKed().Get(field)
+		if qb64, _ := expanded.Saider.Qb64(); string(qb64) != value {
+			t.Fatalf("expected section %s to be compacted to its said: %v", field, value)
+		}
+
+		blocks[field] = *expanded.Block
+	}
+
+	// disclose the attributes alone
+	partial, err := compact.Expand(blocks["a"])
+	if err != nil {
+		t.Fatalf("failed to expand: %v", err)
+	}
+
+	attributes, err := partial.Attributes()
+	if err != nil || attributes.Block == nil || partial.Said() != serder.Said() {
+		t.Fatalf("expected expanded attributes: %v", err)
+	}
+
+	if edges, _ := partial.Edges(); edges.Block != nil {
+		t.Fatalf("expected compact edges")
+	}
+
+	// disclose the edges compactly, then the nested edge
+	qvi, _ := blocks["e"].Get("qvi")
+	qviSaid, _ := qvi.(types.Map).Get("d")
+
+	edges := blocks["e"].Clone()
+	edges.Set("qvi", qviSaid)
+
+	full, err := compact.Expand(blocks["s"], blocks["a"], edges, qvi.(types.Map), blocks["r"])
+	if err != nil {
+		t.Fatalf("failed to expand: %v", err)
+	}
+
+	if string(full.GetRaw()) != string(serder.GetRaw()) {
+		t.Fatalf("expected full disclosure to restore the credential: %s", full.GetRaw())
+	}
+
+	// a disclosed block must hash to the said it replaces
+	tampered := blocks["a"].Clone()
+	tampered.Set("LEI", "984500E3ED6A2B9A1F43")
+
+	if _, err := compact.Expand(tampered); err == nil {
+		t.Fatalf("expected tampered block to be rejected")
+	}
+
+	other := acdcTestMap(t, "d", "", "x", "y")
+	otherKed := acdcTestCredential(t, cesrgo.Kind_JSON)
+
+	otherSerder, err := cesr.NewSerderACDC(nil, nil, &otherKed, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create credential: %v", err)
+	}
+
+	otherAttributes, _ := otherSerder.Attributes()
+	if _, err := compact.Expand(*otherAttributes.Block); err == nil {
+		t.Fatalf("expected block of another credential to be rejected")
+	}
+
+	if _, err := compact.Expand(other); err == nil {
+		t.Fatalf("expected unsaidified block to be rejected")
+	}
+}
@@ -0,0 +1,167 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func aggregateTestAggregator(t *testing.T, kind types.Kind) *cesr.Aggregator {
+	t.Helper()
+
+	aggregator, err := cesr.NewAggregator([]types.Map{
+		acdcTestMap(t, "i", acdcTestIssuee),
+		acdcTestMap(t, "name", "Jane Doe"),
+		acdcTestMap(t, "dob", "1980-01-01"),
+	}, nil, &kind)
+	if err != nil {
+		t.Fatalf("failed to create aggregator: %v", err)
+	}
+
+	return aggregator
+}
+
+func aggregateTestCredential(t *testing.T, aggregate types.List, kind types.Kind) *cesr.SerderACDC {
+	t.Helper()
+
+	ked := acdcTestMap(t,
+		"v", acdcTestVersion(t, cesrgo.VERSION_1_0, kind),
+		"d", "",
+		"i", acdcTestIssuer,
+		"s", acdcTestChained,
+		"A", aggregate,
+	)
+
+	serder, err := cesr.NewSerderACDC(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create credential: %v", err)
+	}
+
+	return serder
+}
+
+func TestAggregateDisclosure(t *testing.T) {
+	aggregator := aggregateTestAggregator(t, cesrgo.Kind_JSON)
+
+	nonces := map[any]bool{}
+	for _, block := range aggregator.Blocks {
+		u, _ := block.Get("u")
+		nonces[u] = true
+	}
+
+	if len(nonces) != len(aggregator.Blocks) {
+		t.Fatalf("expected each block to be blinded by its own nonce")
+	}
+
+	aggregate, err := aggregator.Disclose(1)
+	if err != nil {
+		t.Fatalf("failed to disclose: %v", err)
+	}
+
+	diger, disclosed, err := cesr.VerifyAggregate(aggregate, cesrgo.Kind_JSON)
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	agid, _ := aggregator.Diger.Qb64()
+	if qb64, _ := diger.Qb64(); qb64 != agid || len(disclosed) != 1 {
+		t.Fatalf("unexpected disclosure: %s %d", qb64, len(disclosed))
+	}
+
+	if name, _ := disclosed[0].Get("name"); name != "Jane Doe" {
+		t.Fatalf("unexpected disclosed block: %v", name)
+	}
+
+	// a disclosed block must hash to its said, and the saids to the identifier
+	tampered := aggregator.Blocks[1].Clone()
+	tampered.Set("name", "John Doe")
+	aggregate[2] = tampered
+
+	if _, _, err := cesr.VerifyAggregate(aggregate, cesrgo.Kind_JSON); err == nil {
+		t.Fatalf("expected tampered block to be rejected")
+	}
+
+	reordered, err := aggregator.Disclose()
+	if err != nil {
+		t.Fatalf("failed to disclose: %v", err)
+	}
+
+	reordered[1], reordered[2] = reordered[2], reordered[1]
+	if _, _, err := cesr.VerifyAggregate(reordered, cesrgo.Kind_JSON); err == nil {
+		t.Fatalf("expected reordered aggregate to be rejected")
+	}
+
+	if _, err := aggregator.Disclose(3); err == nil {
+		t.Fatalf("expected missing block to be rejected")
+	}
+
+	if _, err := cesr.NewAggregator([]types.Map{acdcTestMap(t, "u", "")}, nil, nil); err == nil {
+		t.Fatalf("expected reserved label to be rejected")
+	}
+}
+
+func TestAggregateCredential(t *testing.T) {
+	for _, kind := range []types.Kind{cesrgo.Kind_JSON, cesrgo.Kind_CBOR} {
+		aggregator := aggregateTestAggregator(t, kind)
+
+		undisclosed, err := aggregator.Disclose()
+		if err != nil {
+			t.Fatalf("failed to disclose: %v", err)
+		}
+
+		serder := aggregateTestCredential(t, undisclosed, kind)
+
+		compact, err := serder.Compact()
+		if err != nil {
+			t.Fatalf("failed to compact: %v", err)
+		}
+
+		agid, _ := aggregator.Diger.Qb64()
+		if a, _ := compact.GetKed().Get("A"); a != string(agid) || compact.Said() != serder.Said() {
+			t.Fatalf("expected aggregate to compact to its identifier: %v", a)
+		}
+
+		partial, err := aggregator.Disclose(0, 2)
+		if err != nil {
+			t.Fatalf("failed to disclose: %v", err)
+		}
+
+		disclosed, err := compact.DiscloseAggregate(partial)
+		if err != nil {
+			t.Fatalf("failed to disclose aggregate: %v", err)
+		}
+
+		raw := disclosed.GetRaw()
+
+		loaded, err := cesr.NewSerderACDC(nil, &raw, nil, nil, true)
+		if err != nil || loaded.Said() != serder.Said() {
+			t.Fatalf("failed to verify disclosure (%s): %v", kind, err)
+		}
+
+		diger, blocks, err := loaded.Aggregate()
+		if qb64, _ := diger.Qb64(); err != nil || qb64 != agid || len(blocks) != 2 {
+			t.Fatalf("unexpected aggregate: %d blocks (%v)", len(blocks), err)
+		}
+
+		// blocks may also be disclosed by expansion of their saids
+		expanded, err := serder.Expand(aggregator.Blocks[1])
+		if err != nil {
+			t.Fatalf("failed to expand: %v", err)
+		}
+
+		if _, blocks, err := expanded.Aggregate(); err != nil || len(blocks) != 1 {
+			t.Fatalf("unexpected expanded aggregate: %v", err)
+		}
+
+		other, err := aggregateTestAggregator(t, kind).Disclose(0)
+		if err != nil {
+			t.Fatalf("failed to disclose: %v", err)
+		}
+
+		if _, err := compact.DiscloseAggregate(other); err == nil {
+			t.Fatalf("expected aggregate of another credential to be rejected")
+		}
+	}
+}
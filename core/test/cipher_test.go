@@ -0,0 +1,325 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo/common"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	mdex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func TestCipherSigner(t *testing.T) {
+	decrypter, err := cesr.NewDecrypter(nil)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	testCases := []struct {
+		Code         types.Code
+		Transferable bool
+	}{
+		{Code: mdex.Ed25519_Seed, Transferable: true},
+		{Code: mdex.Ed25519_Seed, Transferable: false},
+		{Code: mdex.ECDSA_256k1_Seed, Transferable: true},
+		{Code: mdex.ECDSA_256r1_Seed, Transferable: false},
+	}
+
+	for _, testCase := range testCases {
+		label := fmt.Sprintf("%s[%t]", testCase.Code, testCase.Transferable)
+		t.Run(label, func(t *testing.T) {
+			signer, err := cesr.NewSigner(testCase.Transferable, options.WithCode(testCase.Code))
+			if err != nil {
+				t.Fatalf("failed to create signer: %v", err)
+			}
+
+			cipher, err := decrypter.GetEncrypter().Encrypt(signer)
+			if err != nil {
+				t.Fatalf("failed to encrypt: %v", err)
+			}
+
+			qb64, err := cipher.Qb64()
+			if err != nil {
+				t.Fatalf("failed to get qb64: %v", err)
+			}
+
+			if cipher.GetCode() != mdex.X25519_Cipher_Seed || len(qb64) != 124 {
+				t.Fatalf("unexpected cipher: %s", qb64)
+			}
+
+			parsed, err := cesr.NewCipher(options.WithQb64(qb64))
+			if err != nil {
+				t.Fatalf("failed to parse cipher: %v", err)
+			}
+
+			decrypted, err := decrypter.DecryptSigner(parsed, testCase.Transferable)
+			if err != nil {
+				t.Fatalf("failed to decrypt: %v", err)
+			}
+
+			if decrypted.GetCode() != signer.GetCode() || !bytes.Equal(decrypted.GetRaw(), signer.GetRaw()) {
+				t.Fatalf("seed mismatch")
+			}
+
+			if decrypted.GetVerfer().GetCode() != signer.GetVerfer().GetCode() {
+				t.Fatalf("verfer code mismatch: %s != %s", decrypted.GetVerfer().GetCode(), signer.GetVerfer().GetCode())
+			}
+
+			if _, err := decrypter.DecryptSalter(parsed, nil); err == nil {
+				t.Fatalf("expected seed cipher not to decrypt as a salt")
+			}
+		})
+	}
+}
+
+func TestCipherSalter(t *testing.T) {
+	signer, err := cesr.NewSigner(true)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	encrypter, err := cesr.NewEncrypter(signer.GetVerfer())
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	decrypter, err := cesr.NewDecrypter(signer)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	salter, err := cesr.NewSalter(&common.TIER_LOW)
+	if err != nil {
+		t.Fatalf("failed to create salter: %v", err)
+	}
+
+	cipher, err := encrypter.Encrypt(salter)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	qb64, err := cipher.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if cipher.GetCode() != mdex.X25519_Cipher_Salt || len(qb64) != 100 {
+		t.Fatalf("unexpected cipher: %s", qb64)
+	}
+
+	decrypted, err := decrypter.DecryptSalter(cipher, &common.TIER_LOW)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	if decrypted.GetCode() != mdex.Salt_128 || !bytes.Equal(decrypted.GetRaw(), salter.GetRaw()) {
+		t.Fatalf("salt mismatch")
+	}
+
+	if _, err := decrypter.DecryptSigner(cipher, true); err == nil {
+		t.Fatalf("expected salt cipher not to decrypt as a seed")
+	}
+
+	other, err := cesr.NewDecrypter(nil)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	if _, err := other.DecryptSalter(cipher, nil); err == nil {
+		t.Fatalf("expected other decrypter to fail")
+	}
+}
+
+func TestCipherCodes(t *testing.T) {
+	decrypter, err := cesr.NewDecrypter(nil)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	signer, err := cesr.NewSigner(true, options.WithCode(mdex.Ed448_Seed))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	if _, err := decrypter.GetEncrypter().Encrypt(signer); err == nil || !strings.Contains(err.Error(), "EncryptQb64") {
+		t.Fatalf("expected seed too large for a cipher to be rejected: %v", err)
+	}
+
+	if _, err := decrypter.GetEncrypter().Encrypt(signer.GetVerfer()); err == nil {
+		t.Fatalf("expected verfer to be rejected")
+	}
+
+	if _, err := cesr.NewCipher(options.WithCode(mdex.Ed25519_Seed), options.WithRaw(make(types.Raw, 32))); err == nil {
+		t.Fatalf("expected seed code to be rejected")
+	}
+}
+
+func TestCipherLeadSizes(t *testing.T) {
+	decrypter, err := cesr.NewDecrypter(nil)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	// ciphertext is plaintext plus 48 bytes of overhead
+	testCases := []struct {
+		Size int
+		Code types.Code
+	}{
+		{Size: 3, Code: mdex.X25519_Cipher_L0},
+		{Size: 2, Code: mdex.X25519_Cipher_L1},
+		{Size: 1, Code: mdex.X25519_Cipher_L2},
+		{Size: 12237, Code: mdex.X25519_Cipher_L0},
+		{Size: 12291, Code: mdex.X25519_Cipher_Big_L0},
+		{Size: 12290, Code: mdex.X25519_Cipher_Big_L1},
+		{Size: 12289, Code: mdex.X25519_Cipher_Big_L2},
+	}
+
+	for _, testCase := range testCases {
+		label := fmt.Sprintf("%d", testCase.Size)
+		t.Run(label, func(t *testing.T) {
+			plain := bytes.Repeat([]byte{'x'}, testCase.Size)
+
+			cipher, err := decrypter.GetEncrypter().EncryptStream(plain)
+			if err != nil {
+				t.Fatalf("failed to encrypt: %v", err)
+			}
+
+			if cipher.GetCode() != testCase.Code {
+				t.Fatalf("cipher code mismatch: %s != %s", cipher.GetCode(), testCase.Code)
+			}
+
+			qb2, err := cipher.Qb2()
+			if err != nil {
+				t.Fatalf("failed to get qb2: %v", err)
+			}
+
+			parsed, err := cesr.NewCipher(options.WithQb2(qb2))
+			if err != nil {
+				t.Fatalf("failed to parse cipher: %v", err)
+			}
+
+			decrypted, err := decrypter.Decrypt(parsed)
+			if err != nil {
+				t.Fatalf("failed to decrypt: %v", err)
+			}
+
+			if !bytes.Equal(decrypted, plain) {
+				t.Fatalf("plaintext mismatch")
+			}
+		})
+	}
+}
+
+func TestCipherStream(t *testing.T) {
+	signers, verfers, digers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, digers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	stream := keveryTestMessage(t, icp, keverTestSign(t, icp, signers), nil)
+
+	decrypter, err := cesr.NewDecrypter(signers[0])
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	encrypter, err := cesr.NewEncrypter(verfers[0])
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	cipher, err := encrypter.EncryptStream(stream)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	if !common.ValidateCode(cipher.GetCode(), mdex.StreamCipherCodex) {
+		t.Fatalf("unexpected code: %s", cipher.GetCode())
+	}
+
+	msgs, err := decrypter.DecryptStream(cipher)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	if len(msgs) != 1 || !bytes.Equal(msgs[0].Raw, icp.GetRaw()) || len(msgs[0].ControllerIdxSigs) != 1 {
+		t.Fatalf("unexpected messages: %d", len(msgs))
+	}
+
+	if _, err := decrypter.DecryptQb64(cipher); err == nil {
+		t.Fatalf("expected stream cipher not to decrypt as qb64")
+	}
+}
+
+func TestCipherPrimitives(t *testing.T) {
+	decrypter, err := cesr.NewDecrypter(nil)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	encrypter := decrypter.GetEncrypter()
+
+	text := "the quick brown fox"
+	texter, err := cesr.NewTexter(&text, options.WithCode(mdex.Bytes_L0))
+	if err != nil {
+		t.Fatalf("failed to create texter: %v", err)
+	}
+
+	signer, err := cesr.NewSigner(true, options.WithCode(mdex.Ed448_Seed))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	for _, prim := range []types.Matter{texter, signer, signer.GetVerfer()} {
+		expected, err := prim.Qb64()
+		if err != nil {
+			t.Fatalf("failed to get qb64: %v", err)
+		}
+
+		cipher, err := encrypter.EncryptQb64(prim)
+		if err != nil {
+			t.Fatalf("failed to encrypt: %v", err)
+		}
+
+		if !common.ValidateCode(cipher.GetCode(), mdex.QB64CipherCodex) {
+			t.Fatalf("unexpected code: %s", cipher.GetCode())
+		}
+
+		decrypted, err := decrypter.DecryptQb64(cipher)
+		if err != nil {
+			t.Fatalf("failed to decrypt: %v", err)
+		}
+
+		if qb64, _ := decrypted.Qb64(); qb64 != expected {
+			t.Fatalf("qb64 mismatch: %s != %s", qb64, expected)
+		}
+
+		cipher, err = encrypter.EncryptQb2(prim)
+		if err != nil {
+			t.Fatalf("failed to encrypt: %v", err)
+		}
+
+		if !common.ValidateCode(cipher.GetCode(), mdex.QB2CipherCodex) {
+			t.Fatalf("unexpected code: %s", cipher.GetCode())
+		}
+
+		decrypted, err = decrypter.DecryptQb2(cipher)
+		if err != nil {
+			t.Fatalf("failed to decrypt: %v", err)
+		}
+
+		if qb64, _ := decrypted.Qb64(); qb64 != expected {
+			t.Fatalf("qb64 mismatch: %s != %s", qb64, expected)
+		}
+
+		if _, err := decrypter.DecryptQb64(cipher); err == nil {
+			t.Fatalf("expected qb2 cipher not to decrypt as qb64")
+		}
+	}
+}
@@ -3,7 +3,9 @@ package test
 import (
 	"testing"
 
+	"github.com/jasoncolburne/cesrgo"
 	cesr "github.com/jasoncolburne/cesrgo/core"
+	"github.com/jasoncolburne/cesrgo/core/counter/one"
 	"github.com/jasoncolburne/cesrgo/core/counter/options"
 	"github.com/jasoncolburne/cesrgo/core/counter/two"
 	"github.com/jasoncolburne/cesrgo/core/types"
@@ -64,3 +66,66 @@ func TestCounterRoundTrip(t *testing.T) {
 		t.Fatalf("counter code mismatch: expected %s, got %s", two.BigAttachmentGroup, qb64bCounter.GetCode())
 	}
 }
+
+func TestCounterVersions(t *testing.T) {
+	v1, err := cesr.NewCounter(
+		options.WithCode(one.AttachmentGroup),
+		options.WithCount(types.Count(2)),
+		options.WithVersion(cesrgo.VERSION_1_0),
+	)
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	qb64, err := v1.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if qb64 != "-VAC" {
+		t.Fatalf("qb64 mismatch: %s", qb64)
+	}
+
+	// -V is not an attachment group in the v2 table
+	v2, err := cesr.NewCounter(options.WithQb64(qb64))
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	if v2.GetCode() == two.AttachmentGroup {
+		t.Fatalf("v1 code decoded as v2 attachment group")
+	}
+
+	parsed, err := cesr.NewCounter(options.WithQb64(qb64), options.WithVersion(cesrgo.VERSION_1_0))
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	if parsed.GetCode() != one.AttachmentGroup || parsed.GetCount() != 2 {
+		t.Fatalf("unexpected v1 counter: %s %d", parsed.GetCode(), parsed.GetCount())
+	}
+
+	count, err := cesr.GenusVersionCount(cesrgo.VERSION_1_0)
+	if err != nil {
+		t.Fatalf("failed to encode genus version: %v", err)
+	}
+
+	genus, err := cesr.NewCounter(options.WithCode(two.KERIACDCGenusVersion), options.WithCount(count))
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	qb64, err = genus.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if qb64 != "-_AAABAA" {
+		t.Fatalf("qb64 mismatch: %s", qb64)
+	}
+
+	version, err := genus.GenusVersion()
+	if err != nil || version != cesrgo.VERSION_1_0 {
+		t.Fatalf("unexpected genus version: %v %v", version, err)
+	}
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"testing"
+
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	mdex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+)
+
+func TestDecrypterConversion(t *testing.T) {
+	signer, err := cesr.NewSigner(true, options.WithQb64(encrypterTestSeed))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	decrypter, err := cesr.NewDecrypter(signer)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	qb64, err := decrypter.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if qb64 != encrypterTestDecrypterSeed {
+		t.Fatalf("decrypter mismatch: %s != %s", qb64, encrypterTestDecrypterSeed)
+	}
+
+	qb64, err = decrypter.GetEncrypter().Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if qb64 != encrypterTestEncrypter {
+		t.Fatalf("encrypter mismatch: %s != %s", qb64, encrypterTestEncrypter)
+	}
+
+	if _, err := cesr.NewDecrypter(signer, options.WithQb64(encrypterTestDecrypterSeed)); err == nil {
+		t.Fatalf("expected signer with qb64 to be rejected")
+	}
+
+	other, err := cesr.NewSigner(true, options.WithCode(mdex.ECDSA_256r1_Seed))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	if _, err := cesr.NewDecrypter(other); err == nil {
+		t.Fatalf("expected %s seed not to convert", mdex.ECDSA_256r1_Seed)
+	}
+}
+
+func TestDecrypterGeneration(t *testing.T) {
+	decrypter, err := cesr.NewDecrypter(nil)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	if decrypter.GetCode() != mdex.X25519_Private || decrypter.GetEncrypter().GetCode() != mdex.X25519 {
+		t.Fatalf("unexpected codes: %s %s", decrypter.GetCode(), decrypter.GetEncrypter().GetCode())
+	}
+
+	qb64, err := decrypter.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	restored, err := cesr.NewDecrypter(nil, options.WithQb64(qb64))
+	if err != nil {
+		t.Fatalf("failed to restore decrypter: %v", err)
+	}
+
+	encrypter, err := restored.GetEncrypter().Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	expected, err := decrypter.GetEncrypter().Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if encrypter != expected {
+		t.Fatalf("encrypter mismatch: %s != %s", encrypter, expected)
+	}
+
+	if _, err := cesr.NewDecrypter(nil, options.WithCode(mdex.Ed25519_Seed)); err == nil {
+		t.Fatalf("expected signing seed code to be rejected")
+	}
+}
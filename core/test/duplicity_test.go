@@ -0,0 +1,176 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func duplicityTestSeal(said string) []types.Map {
+	seal := types.NewMap()
+	seal.Set("d", said)
+
+	return []types.Map{seal}
+}
+
+func TestDuplicityLikely(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	_, _, nextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	kever, _ := kevery.Kever(types.Qb64(icp.Pre()))
+	state := *kever.State()
+
+	ixn, err := cesr.Interact(&state)
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	conflicting, err := cesr.Interact(&state, eopts.WithData(duplicityTestSeal(icp.Said())))
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), nil); err != nil {
+		t.Fatalf("failed to accept interaction: %v", err)
+	}
+
+	// an unsigned conflict is not evidence
+	otherSigners, _, _ := eventingTestKeys(t, 1, true)
+	if err := kevery.ProcessEvent(conflicting, keverTestSign(t, conflicting, otherSigners), nil); err == nil {
+		t.Fatalf("expected unauthorized conflict to be rejected")
+	}
+
+	if len(kevery.Duplicities(types.Qb64(icp.Pre()))) != 0 {
+		t.Fatalf("unexpected duplicity recorded")
+	}
+
+	err = kevery.ProcessEvent(conflicting, keverTestSign(t, conflicting, signers), nil)
+
+	var duplicitous *cesr.DuplicityError
+	if !errors.As(err, &duplicitous) {
+		t.Fatalf("expected duplicity error, got %v", err)
+	}
+
+	duplicities := kevery.Duplicities(types.Qb64(icp.Pre()))
+	if len(duplicities) != 1 || duplicities[0].Kind != cesr.Duplicity_Likely || duplicities[0].Sn.Int64() != 1 {
+		t.Fatalf("unexpected duplicities: %v", duplicities)
+	}
+
+	// the accepted log is unchanged
+	if kever.State().Dig != types.Qb64(ixn.Said()) {
+		t.Fatalf("unexpected state")
+	}
+
+	evidence, err := duplicities[0].Evidence()
+	if err != nil {
+		t.Fatalf("failed to serialize evidence: %v", err)
+	}
+
+	msgs, err := cesr.NewParser(bytes.NewReader(evidence)).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse evidence: %v", err)
+	}
+
+	if len(msgs) != 2 || !bytes.Equal(msgs[0].Raw, ixn.GetRaw()) || !bytes.Equal(msgs[1].Raw, conflicting.GetRaw()) {
+		t.Fatalf("unexpected evidence: %s", evidence)
+	}
+
+	if len(msgs[0].ControllerIdxSigs) != 1 || len(msgs[1].ControllerIdxSigs) != 1 {
+		t.Fatalf("expected signatures in evidence")
+	}
+
+	// a watcher receiving the evidence detects the duplicity too
+	watcher := cesr.NewKevery()
+	if err := watcher.ProcessEvent(icp, keverTestSign(t, icp, signers), nil); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	if err := watcher.ProcessStream(bytes.NewReader(evidence)); !errors.As(err, &duplicitous) {
+		t.Fatalf("expected duplicity error, got %v", err)
+	}
+
+	if len(watcher.Duplicities(types.Qb64(icp.Pre()))) != 1 {
+		t.Fatalf("expected watcher to record duplicity")
+	}
+}
+
+func TestDuplicityRecovery(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	nextSigners, nextVerfers, nextDigers := eventingTestKeys(t, 1, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	kever, _ := kevery.Kever(types.Qb64(icp.Pre()))
+	established := *kever.State()
+
+	for range 2 {
+		ixn, err := cesr.Interact(kever.State())
+		if err != nil {
+			t.Fatalf("failed to interact: %v", err)
+		}
+
+		if err := kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), nil); err != nil {
+			t.Fatalf("failed to accept interaction: %v", err)
+		}
+	}
+
+	// rotate from the inception, superseding both interactions
+	rot, err := cesr.Rotate(&established, nextVerfers, nextNextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(rot, keverTestSign(t, rot, nextSigners), nil); err != nil {
+		t.Fatalf("failed to recover: %v", err)
+	}
+
+	state := kever.State()
+	if state.Sn.Int64() != 1 || state.EstSn.Int64() != 1 || state.Dig != types.Qb64(rot.Said()) {
+		t.Fatalf("unexpected state after recovery: sn %s", state.Sn.Text(16))
+	}
+
+	duplicities := kevery.Duplicities(types.Qb64(icp.Pre()))
+	if len(duplicities) != 1 || duplicities[0].Kind != cesr.Duplicity_Recovery || len(duplicities[0].Superseded) != 2 {
+		t.Fatalf("unexpected duplicities: %v", duplicities)
+	}
+
+	// a rotation cannot supersede an establishment event
+	conflicting, err := cesr.Rotate(&established, nextVerfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	err = kevery.ProcessEvent(conflicting, keverTestSign(t, conflicting, nextSigners), nil)
+
+	var duplicitous *cesr.DuplicityError
+	if !errors.As(err, &duplicitous) || duplicitous.Duplicity.Kind != cesr.Duplicity_Likely {
+		t.Fatalf("expected likely duplicity, got %v", err)
+	}
+
+	if kever.State().Dig != types.Qb64(rot.Said()) {
+		t.Fatalf("unexpected state")
+	}
+}
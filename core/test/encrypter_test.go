@@ -0,0 +1,109 @@
+package test
+
+import (
+	"testing"
+
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	mdex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// from libsodium's ed25519_convert test
+const (
+	encrypterTestSeed          = types.Qb64("AEIRUaRZ-urePSRxFflK7a5CMYEkCVr6vk0UUaVZ-u3u")
+	encrypterTestVerfer        = types.Qb64("BLUHaoR0qDLa7k3VtAQJg7ZiO180SspX1NbuS68_JZ5u")
+	encrypterTestEncrypter     = types.Qb64("CPGBTw6P8QQ9ikTSW6v_PO3K5sIsPtqkj4V65w3iuq5Q")
+	encrypterTestDecrypterSeed = types.Qb64("OIBSAwN21HESvn9z7XoBkpPdEq2RC2VEVXmLRmfXPeFm")
+)
+
+func TestEncrypterConversion(t *testing.T) {
+	for _, transferable := range []bool{true, false} {
+		signer, err := cesr.NewSigner(transferable, options.WithQb64(encrypterTestSeed))
+		if err != nil {
+			t.Fatalf("failed to create signer: %v", err)
+		}
+
+		encrypter, err := cesr.NewEncrypter(signer.GetVerfer())
+		if err != nil {
+			t.Fatalf("failed to create encrypter: %v", err)
+		}
+
+		qb64, err := encrypter.Qb64()
+		if err != nil {
+			t.Fatalf("failed to get qb64: %v", err)
+		}
+
+		if qb64 != encrypterTestEncrypter {
+			t.Fatalf("encrypter mismatch: %s != %s", qb64, encrypterTestEncrypter)
+		}
+
+		verified, err := encrypter.VerifySeed(signer)
+		if err != nil || !verified {
+			t.Fatalf("expected seed to verify: %v", err)
+		}
+	}
+
+	verfer, err := cesr.NewVerfer(options.WithQb64(encrypterTestVerfer))
+	if err != nil {
+		t.Fatalf("failed to create verfer: %v", err)
+	}
+
+	if _, err := cesr.NewEncrypter(verfer, options.WithQb64(encrypterTestEncrypter)); err == nil {
+		t.Fatalf("expected verfer with qb64 to be rejected")
+	}
+
+	other, err := cesr.NewSigner(true)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	encrypter, err := cesr.NewEncrypter(nil, options.WithQb64(encrypterTestEncrypter))
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	verified, err := encrypter.VerifySeed(other)
+	if err != nil || verified {
+		t.Fatalf("expected other seed not to verify: %v", err)
+	}
+}
+
+func TestEncrypterCodes(t *testing.T) {
+	encrypter, err := cesr.NewEncrypter(nil, options.WithRaw(make(types.Raw, 32)))
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	if encrypter.GetCode() != mdex.X25519 {
+		t.Fatalf("encrypter code mismatch: %s", encrypter.GetCode())
+	}
+
+	if _, err := cesr.NewEncrypter(nil, options.WithQb64(encrypterTestVerfer)); err == nil {
+		t.Fatalf("expected verification key to be rejected")
+	}
+
+	for _, code := range []types.Code{mdex.ECDSA_256k1_Seed, mdex.ECDSA_256r1_Seed, mdex.Ed448_Seed} {
+		signer, err := cesr.NewSigner(true, options.WithCode(code))
+		if err != nil {
+			t.Fatalf("failed to create signer: %v", err)
+		}
+
+		if _, err := cesr.NewEncrypter(signer.GetVerfer()); err == nil {
+			t.Fatalf("expected %s verfer not to convert", code)
+		}
+	}
+
+	// the identity point has small order
+	identity := make(types.Raw, 32)
+	identity[0] = 1
+
+	verfer, err := cesr.NewVerfer(options.WithCode(mdex.Ed25519), options.WithRaw(identity))
+	if err != nil {
+		t.Fatalf("failed to create verfer: %v", err)
+	}
+
+	if _, err := cesr.NewEncrypter(verfer); err == nil {
+		t.Fatalf("expected identity point to be rejected")
+	}
+}
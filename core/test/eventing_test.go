@@ -0,0 +1,315 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func eventingTestKeys(t *testing.T, count int, transferable bool) ([]*cesr.Signer, []*cesr.Verfer, []*cesr.Diger) {
+	t.Helper()
+
+	signers := make([]*cesr.Signer, count)
+	verfers := make([]*cesr.Verfer, count)
+	digers := make([]*cesr.Diger, count)
+
+	for i := range count {
+		signer, err := cesr.NewSigner(transferable)
+		if err != nil {
+			t.Fatalf("failed to create signer: %v", err)
+		}
+
+		key, err := signer.GetVerfer().Qb64()
+		if err != nil {
+			t.Fatalf("failed to get qb64: %v", err)
+		}
+
+		diger, err := cesr.NewDiger([]byte(key), options.WithCode(codex.Blake3_256))
+		if err != nil {
+			t.Fatalf("failed to create diger: %v", err)
+		}
+
+		signers[i] = signer
+		verfers[i] = signer.GetVerfer()
+		digers[i] = diger
+	}
+
+	return signers, verfers, digers
+}
+
+func TestInceptSelfAddressing(t *testing.T) {
+	_, verfers, _ := eventingTestKeys(t, 3, true)
+	_, _, digers := eventingTestKeys(t, 3, true)
+	_, wits, _ := eventingTestKeys(t, 4, false)
+
+	witPres := make([]types.Qb64, len(wits))
+	for i, wit := range wits {
+		qb64, err := wit.Qb64()
+		if err != nil {
+			t.Fatalf("failed to get qb64: %v", err)
+		}
+
+		witPres[i] = qb64
+	}
+
+	for _, kind := range []types.Kind{cesrgo.Kind_JSON, cesrgo.Kind_CBOR, cesrgo.Kind_MGPK, cesrgo.Kind_CESR} {
+		serder, err := cesr.Incept(
+			verfers,
+			digers,
+			eopts.WithNextSith([]any{"1/2", "1/2", "1/2"}),
+			eopts.WithWits(witPres),
+			eopts.WithTraits([]types.Trait{cesrgo.Trait_EstOnly}),
+			eopts.WithKind(kind),
+		)
+		if err != nil {
+			t.Fatalf("failed to incept (%s): %v", kind, err)
+		}
+
+		if serder.Ilk() != cesrgo.Ilk_ICP || serder.Pre() != serder.Said() {
+			t.Fatalf("expected self-addressing inception: %s %s", serder.Pre(), serder.Said())
+		}
+
+		prefixer, err := serder.Prefixer()
+		if err != nil || prefixer.GetCode() != codex.Blake3_256 {
+			t.Fatalf("unexpected prefixer: %v", err)
+		}
+
+		// loading with saidify verifies both d and i
+		raw := serder.GetRaw()
+		if _, err := cesr.NewSerderKERI(nil, &raw, nil, nil, true); err != nil {
+			t.Fatalf("failed to verify (%s): %v", kind, err)
+		}
+
+		tholder, err := serder.Tholder()
+		if err != nil || tholder.Weighted() || tholder.Thold() != 2 {
+			t.Fatalf("unexpected default sith: %v", err)
+		}
+
+		toad, err := serder.BackerNumber()
+		if err != nil {
+			t.Fatalf("failed to get toad: %v", err)
+		}
+
+		if n := toad.Number(); n.Int64() != 3 {
+			t.Fatalf("unexpected default toad: %s", toad.Hex())
+		}
+	}
+}
+
+func TestInceptBasic(t *testing.T) {
+	_, verfers, digers := eventingTestKeys(t, 1, true)
+
+	serder, err := cesr.Incept(verfers, digers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	key, err := verfers[0].Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if serder.Pre() != string(key) || serder.Said() == serder.Pre() {
+		t.Fatalf("expected basic prefix: %s", serder.Pre())
+	}
+
+	raw := serder.GetRaw()
+	if _, err := cesr.NewSerderKERI(nil, &raw, nil, nil, true); err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	_, nonTrans, _ := eventingTestKeys(t, 1, false)
+	if _, err := cesr.Incept(nonTrans, nil); err != nil {
+		t.Fatalf("failed to incept non-transferable: %v", err)
+	}
+
+	if _, err := cesr.Incept(nonTrans, digers); err == nil {
+		t.Fatalf("expected non-transferable prefix with next keys to be rejected")
+	}
+}
+
+func TestInceptDelegated(t *testing.T) {
+	_, verfers, digers := eventingTestKeys(t, 1, true)
+
+	delegator, err := cesr.Incept(verfers, digers, eopts.WithCode(codex.Blake3_256))
+	if err != nil {
+		t.Fatalf("failed to incept delegator: %v", err)
+	}
+
+	serder, err := cesr.Incept(verfers, digers, eopts.WithDelegator(types.Qb64(delegator.Pre())))
+	if err != nil {
+		t.Fatalf("failed to incept delegate: %v", err)
+	}
+
+	if serder.Ilk() != cesrgo.Ilk_DIP || serder.Pre() != serder.Said() {
+		t.Fatalf("expected self-addressing delegated inception")
+	}
+
+	di, err := serder.Delegator()
+	if err != nil {
+		t.Fatalf("failed to get delegator: %v", err)
+	}
+
+	qb64, err := di.Qb64()
+	if err != nil || string(qb64) != delegator.Pre() {
+		t.Fatalf("unexpected delegator: %s", qb64)
+	}
+}
+
+func TestInceptInvalid(t *testing.T) {
+	_, verfers, digers := eventingTestKeys(t, 2, true)
+
+	tests := []struct {
+		name string
+		opts []eopts.EventOption
+	}{
+		{"sith too large", []eopts.EventOption{eopts.WithSith("3")}},
+		{"zero sith", []eopts.EventOption{eopts.WithSith("0")}},
+		{"next sith too large", []eopts.EventOption{eopts.WithNextSith([]any{"1/3", "1/3", "1/3"})}},
+		{"toad without witnesses", []eopts.EventOption{eopts.WithToad(1)}},
+		{"basic with many keys", []eopts.EventOption{eopts.WithCode(codex.Ed25519)}},
+	}
+
+	for _, test := range tests {
+		if _, err := cesr.Incept(verfers, digers, test.opts...); err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestRotateAndInteract(t *testing.T) {
+	_, verfers, digers := eventingTestKeys(t, 2, true)
+	_, nextVerfers, nextDigers := eventingTestKeys(t, 2, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 2, true)
+	_, wits, _ := eventingTestKeys(t, 3, false)
+
+	witPres := make([]types.Qb64, len(wits))
+	for i, wit := range wits {
+		qb64, err := wit.Qb64()
+		if err != nil {
+			t.Fatalf("failed to get qb64: %v", err)
+		}
+
+		witPres[i] = qb64
+	}
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithNextSith("2"), eopts.WithWits(witPres[:2]))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	state, err := cesr.NewKeyState(icp)
+	if err != nil {
+		t.Fatalf("failed to create key state: %v", err)
+	}
+
+	ixn, err := cesr.Interact(state)
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if ixn.Ilk() != cesrgo.Ilk_IXN || ixn.Pre() != icp.Pre() {
+		t.Fatalf("unexpected interaction: %s", ixn.GetRaw())
+	}
+
+	state, err = state.Apply(ixn)
+	if err != nil {
+		t.Fatalf("failed to apply interaction: %v", err)
+	}
+
+	// the keys must be those committed to by the prior next digests
+	if _, err := cesr.Rotate(state, verfers, digers); err == nil {
+		t.Fatalf("expected unexposed rotation keys to be rejected")
+	}
+
+	// a single exposed key does not satisfy a threshold of 2
+	if _, err := cesr.Rotate(state, nextVerfers[:1], nextNextDigers, eopts.WithSith("1")); err == nil {
+		t.Fatalf("expected unsatisfied prior next threshold to be rejected")
+	}
+
+	if _, err := cesr.Rotate(state, nextVerfers, nextNextDigers, eopts.WithCuts(witPres[2:])); err == nil {
+		t.Fatalf("expected cut of non witness to be rejected")
+	}
+
+	rot, err := cesr.Rotate(
+		state,
+		nextVerfers,
+		nextNextDigers,
+		eopts.WithCuts(witPres[:1]),
+		eopts.WithAdds(witPres[2:]),
+	)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	prior, err := rot.Prior()
+	if err != nil {
+		t.Fatalf("failed to get prior: %v", err)
+	}
+
+	p, err := prior.Qb64()
+	if err != nil || string(p) != ixn.Said() {
+		t.Fatalf("unexpected prior: %s", p)
+	}
+
+	sn, err := rot.Sn()
+	if err != nil || sn.Int64() != 2 {
+		t.Fatalf("unexpected sn: %v", sn)
+	}
+
+	state, err = state.Apply(rot)
+	if err != nil {
+		t.Fatalf("failed to apply rotation: %v", err)
+	}
+
+	if len(state.Wits) != 2 || state.Wits[0] != witPres[1] || state.Wits[1] != witPres[2] {
+		t.Fatalf("unexpected witnesses: %v", state.Wits)
+	}
+
+	if _, err := state.Apply(rot); err == nil {
+		t.Fatalf("expected replayed rotation to be rejected")
+	}
+}
+
+func TestRotateDelegatedVersion1(t *testing.T) {
+	_, verfers, _ := eventingTestKeys(t, 1, true)
+	_, nextVerfers, nextDigers := eventingTestKeys(t, 1, true)
+
+	dip, err := cesr.Incept(
+		verfers,
+		nextDigers,
+		eopts.WithDelegator("EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU"),
+		eopts.WithTraits([]types.Trait{cesrgo.Trait_EstOnly}),
+		eopts.WithVersion(cesrgo.VERSION_1_0),
+	)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	state, err := cesr.NewKeyState(dip)
+	if err != nil {
+		t.Fatalf("failed to create key state: %v", err)
+	}
+
+	if _, err := cesr.Interact(state); err == nil {
+		t.Fatalf("expected interaction of establishment only identifier to be rejected")
+	}
+
+	if _, err := cesr.Rotate(state, nextVerfers, nil, eopts.WithTraits([]types.Trait{cesrgo.Trait_EstOnly})); err == nil {
+		t.Fatalf("expected v1 rotation traits to be rejected")
+	}
+
+	drt, err := cesr.Rotate(state, nextVerfers, nil)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	if drt.Ilk() != cesrgo.Ilk_DRT || drt.GetVersion() != cesrgo.VERSION_1_0 {
+		t.Fatalf("unexpected rotation: %s", drt.GetRaw())
+	}
+}
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func firstSeenTestClone(t *testing.T, kever *cesr.Kever) *cesr.Kever {
+	t.Helper()
+
+	replay, err := kever.Replay()
+	if err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+
+	clone := cesr.NewKevery()
+	if err := clone.CloneStream(bytes.NewReader(replay)); err != nil {
+		t.Fatalf("failed to process replay: %v", err)
+	}
+
+	cloned, ok := clone.Kever(kever.Pre())
+	if !ok {
+		t.Fatalf("expected replayed prefix to be accepted")
+	}
+
+	for fn := uint64(0); ; fn++ {
+		event, ok := kever.FirstSeen(fn)
+		clonedEvent, clonedOk := cloned.FirstSeen(fn)
+		if ok != clonedOk {
+			t.Fatalf("unexpected first seen log length: %d", fn)
+		}
+
+		if !ok {
+			break
+		}
+
+		if event.Serder.Said() != clonedEvent.Serder.Said() {
+			t.Fatalf("unexpected event first seen at %d", fn)
+		}
+
+		if sn := clonedEvent.FirstSeen.Seqner.Sn(); sn.Uint64() != fn {
+			t.Fatalf("unexpected first seen ordinal: %s", sn.Text(16))
+		}
+
+		dts, err := event.FirstSeen.Dater.DTS()
+		if err != nil {
+			t.Fatalf("failed to get dts: %v", err)
+		}
+
+		clonedDts, err := clonedEvent.FirstSeen.Dater.DTS()
+		if err != nil || dts != clonedDts {
+			t.Fatalf("expected replayed first seen time to be kept: %s != %s", clonedDts, dts)
+		}
+	}
+
+	if cloned.State().Dig != kever.State().Dig {
+		t.Fatalf("unexpected replayed state: %s", cloned.State().Dig)
+	}
+
+	return cloned
+}
+
+func TestFirstSeenReplay(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	witSigners, wits := keveryTestWits(t, 2)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithWits(wits))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	err = kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil)
+	keveryTestEscrowed(t, err, cesr.Escrow_PartiallyWitnessed)
+
+	for _, witSigner := range witSigners {
+		rct := receiptTestWitnessReceipt(t, icp, witSigner)
+		if err := kevery.ProcessStream(bytes.NewReader(rct)); err != nil {
+			t.Fatalf("failed to process receipt: %v", err)
+		}
+	}
+
+	kever, ok := kevery.Kever(types.Qb64(icp.Pre()))
+	if !ok {
+		t.Fatalf("expected witnessed inception to be accepted")
+	}
+
+	ixn, err := cesr.Interact(kever.State())
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	wigers := []*cesr.Siger{}
+	for i, witSigner := range witSigners {
+		//nolint:gosec
+		wiger, err := witSigner.SignIndexed(ixn.GetRaw(), false, types.Index(i), nil)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		wigers = append(wigers, wiger)
+	}
+
+	if err := kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), wigers); err != nil {
+		t.Fatalf("failed to accept interaction: %v", err)
+	}
+
+	event, ok := kever.FirstSeen(1)
+	if !ok || event.Serder.Said() != ixn.Said() || event.FirstSeen.Dater == nil {
+		t.Fatalf("unexpected first seen event")
+	}
+
+	// the inception is only fully witnessed by the receipts replayed with it
+	firstSeenTestClone(t, kever)
+}
+
+func TestFirstSeenRecovery(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	nextSigners, nextVerfers, nextDigers := eventingTestKeys(t, 1, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	kever, _ := kevery.Kever(types.Qb64(icp.Pre()))
+	established := *kever.State()
+
+	ixn, err := cesr.Interact(kever.State())
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), nil); err != nil {
+		t.Fatalf("failed to accept interaction: %v", err)
+	}
+
+	rot, err := cesr.Rotate(&established, nextVerfers, nextNextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(rot, keverTestSign(t, rot, nextSigners), nil); err != nil {
+		t.Fatalf("failed to recover: %v", err)
+	}
+
+	// the superseded interaction keeps its place in the first seen log
+	for fn, said := range []string{icp.Said(), ixn.Said(), rot.Said()} {
+		event, ok := kever.FirstSeen(uint64(fn))
+		if !ok || event.Serder.Said() != said {
+			t.Fatalf("unexpected event first seen at %d", fn)
+		}
+	}
+
+	if event, _ := kever.Event(1); event.Serder.Said() != rot.Said() {
+		t.Fatalf("expected recovery to replace the interaction")
+	}
+
+	firstSeenTestClone(t, kever)
+}
+
+func TestFirstSeenUntrustedReplay(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	// a peer claims the event was first seen long ago
+	backdated := types.DateTime("2000-01-01T00:00:00.000000+00:00")
+	dater, err := cesr.NewDater(&backdated)
+	if err != nil {
+		t.Fatalf("failed to create dater: %v", err)
+	}
+
+	fn, err := cesr.NewSeqner(big.NewInt(0), nil)
+	if err != nil {
+		t.Fatalf("failed to create seqner: %v", err)
+	}
+
+	msg, err := (&cesr.SignedEvent{
+		Serder:    icp,
+		Sigers:    keverTestSign(t, icp, signers),
+		FirstSeen: &cesr.FirstSeenReplayCouple{Seqner: fn, Dater: dater},
+	}).Messagize()
+	if err != nil {
+		t.Fatalf("failed to messagize: %v", err)
+	}
+
+	for _, test := range []struct {
+		clone bool
+		kept  bool
+	}{
+		{clone: false, kept: false},
+		{clone: true, kept: true},
+	} {
+		kevery := cesr.NewKevery()
+
+		process := kevery.ProcessStream
+		if test.clone {
+			process = kevery.CloneStream
+		}
+
+		if err := process(bytes.NewReader(msg)); err != nil {
+			t.Fatalf("failed to process: %v", err)
+		}
+
+		kever, ok := kevery.Kever(types.Qb64(icp.Pre()))
+		if !ok {
+			t.Fatalf("expected inception to be accepted")
+		}
+
+		event, _ := kever.FirstSeen(0)
+		dts, err := event.FirstSeen.Dater.DTS()
+		if err != nil {
+			t.Fatalf("failed to get dts: %v", err)
+		}
+
+		if (dts == backdated) != test.kept {
+			t.Fatalf("unexpected first seen time (clone = %t): %s", test.clone, dts)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo/common"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	mdex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/crypto/x25519"
+)
+
+func hpkeTestController(t *testing.T) (*cesr.Decrypter, *cesr.Encrypter) {
+	t.Helper()
+
+	signer, err := cesr.NewSigner(false)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	decrypter, err := cesr.NewDecrypter(signer)
+	if err != nil {
+		t.Fatalf("failed to create decrypter: %v", err)
+	}
+
+	// the encryption key of a basic AID is converted from its prefix
+	pre, err := signer.GetVerfer().Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	verfer, err := cesr.NewVerfer(options.WithQb64(pre))
+	if err != nil {
+		t.Fatalf("failed to create verfer: %v", err)
+	}
+
+	encrypter, err := cesr.NewEncrypter(verfer)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	return decrypter, encrypter
+}
+
+func TestHPKEBase(t *testing.T) {
+	recipient, encrypter := hpkeTestController(t)
+	plain := []byte("the quick brown fox")
+
+	cipher, err := encrypter.EncryptBase(plain)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	if !common.ValidateCode(cipher.GetCode(), mdex.HPKEBaseCipherCodex) {
+		t.Fatalf("unexpected code: %s", cipher.GetCode())
+	}
+
+	qb64, err := cipher.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	parsed, err := cesr.NewCipher(options.WithQb64(qb64))
+	if err != nil {
+		t.Fatalf("failed to parse cipher: %v", err)
+	}
+
+	decrypted, err := recipient.DecryptBase(parsed)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	if !bytes.Equal(decrypted, plain) {
+		t.Fatalf("plaintext mismatch")
+	}
+
+	other, _ := hpkeTestController(t)
+	if _, err := other.DecryptBase(parsed); err == nil {
+		t.Fatalf("expected other recipient to fail")
+	}
+
+	if _, err := recipient.Decrypt(parsed); err == nil {
+		t.Fatalf("expected hpke cipher not to open as a sealed box")
+	}
+
+	if _, err := recipient.DecryptAuth(parsed, encrypter); err == nil {
+		t.Fatalf("expected base cipher not to open in the auth mode")
+	}
+}
+
+func TestHPKEAuth(t *testing.T) {
+	sender, senderEncrypter := hpkeTestController(t)
+	recipient, recipientEncrypter := hpkeTestController(t)
+	impostor, impostorEncrypter := hpkeTestController(t)
+
+	plain := bytes.Repeat([]byte("attack at dawn "), 1000)
+
+	cipher, err := recipientEncrypter.EncryptAuth(plain, sender)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	if !common.ValidateCode(cipher.GetCode(), mdex.HPKEAuthCipherCodex) {
+		t.Fatalf("unexpected code: %s", cipher.GetCode())
+	}
+
+	qb2, err := cipher.Qb2()
+	if err != nil {
+		t.Fatalf("failed to get qb2: %v", err)
+	}
+
+	parsed, err := cesr.NewCipher(options.WithQb2(qb2))
+	if err != nil {
+		t.Fatalf("failed to parse cipher: %v", err)
+	}
+
+	decrypted, err := recipient.DecryptAuth(parsed, senderEncrypter)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	if !bytes.Equal(decrypted, plain) {
+		t.Fatalf("plaintext mismatch")
+	}
+
+	if _, err := recipient.DecryptAuth(parsed, impostorEncrypter); err == nil {
+		t.Fatalf("expected other sender to fail authentication")
+	}
+
+	if _, err := recipient.DecryptBase(parsed); err == nil {
+		t.Fatalf("expected auth cipher not to open in the base mode")
+	}
+
+	// an impostor cannot encrypt as the sender
+	forged, err := recipientEncrypter.EncryptAuth(plain, impostor)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	if _, err := recipient.DecryptAuth(forged, senderEncrypter); err == nil {
+		t.Fatalf("expected forged cipher to fail authentication")
+	}
+
+	tampered := bytes.Clone(parsed.GetRaw())
+	tampered[len(tampered)-1] ^= 1
+
+	tamperedCipher, err := cesr.NewCipher(options.WithCode(mdex.HPKEAuth_Cipher_L0), options.WithRaw(tampered))
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+
+	if _, err := recipient.DecryptAuth(tamperedCipher, senderEncrypter); err == nil {
+		t.Fatalf("expected tampered cipher to fail")
+	}
+}
+
+// TestHPKEVectors opens the first encryption of the RFC 9180 appendix A.2 test vectors for
+// DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and ChaCha20-Poly1305, in the Base and Auth modes
+func TestHPKEVectors(t *testing.T) {
+	testCases := []struct {
+		Label string
+		SkRm  string
+		PkRm  string
+		SkSm  string
+		PkSm  string
+		Enc   string
+		Ct    string
+	}{
+		{
+			Label: "A.2.1 base",
+			SkRm:  "8057991eef8f1f1af18f4a9491d16a1ce333f695d4db8e38da75975c4478e0fb",
+			PkRm:  "4310ee97d88cc1f088a5576c77ab0cf5c3ac797f3d95139c6c84b5429c59662a",
+			Enc:   "1afa08d3dec047a643885163f1180476fa7ddb54c6a8029ea33f95796bf2ac4a",
+			Ct:    "1c5250d8034ec2b784ba2cfd69dbdb8af406cfe3ff938e131f0def8c8b60b4db21993c62ce81883d2dd1b51a28",
+		},
+		{
+			Label: "A.2.3 auth",
+			SkRm:  "3ca22a6d1cda1bb9480949ec5329d3bf0b080ca4c45879c95eddb55c70b80b82",
+			PkRm:  "1a478716d63cb2e16786ee93004486dc151e988b34b475043d3e0175bdb01c44",
+			SkSm:  "2def0cb58ffcf83d1062dd085c8aceca7f4c0c3fd05912d847b61f3e54121f05",
+			PkSm:  "f0f4f9e96c54aeed3f323de8534fffd7e0577e4ce269896716bcb95643c8712b",
+			Enc:   "f7674cc8cd7baa5872d1f33dbaffe3314239f6197ddf5ded1746760bfc847e0e",
+			Ct:    "ab1a13c9d4f01a87ec3440dbd756e2677bd2ecf9df0ce7ed73869b98e00c09be111cb9fdf077347aeb88e61bdf",
+		},
+	}
+
+	info, _ := hex.DecodeString("4f6465206f6e2061204772656369616e2055726e")
+	aad, _ := hex.DecodeString("436f756e742d30")
+	pt, _ := hex.DecodeString("4265617574792069732074727574682c20747275746820626561757479")
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			skRm, _ := hex.DecodeString(testCase.SkRm)
+			pkRm, _ := hex.DecodeString(testCase.PkRm)
+			enc, _ := hex.DecodeString(testCase.Enc)
+			ct, _ := hex.DecodeString(testCase.Ct)
+
+			var skSm, pkSm []byte
+			if testCase.PkSm != "" {
+				skSm, _ = hex.DecodeString(testCase.SkSm)
+				pkSm, _ = hex.DecodeString(testCase.PkSm)
+			}
+
+			pub, err := x25519.DerivePublicKey(skRm)
+			if err != nil {
+				t.Fatalf("failed to derive public key: %v", err)
+			}
+
+			if !bytes.Equal(pub, pkRm) {
+				t.Fatalf("public key mismatch")
+			}
+
+			plain, err := x25519.OpenHPKE(skRm, pkSm, info, aad, append(enc, ct...))
+			if err != nil {
+				t.Fatalf("failed to open: %v", err)
+			}
+
+			if !bytes.Equal(plain, pt) {
+				t.Fatalf("plaintext mismatch: %x", plain)
+			}
+
+			if _, err := x25519.OpenHPKE(skRm, pkSm, info, nil, append(enc, ct...)); err == nil {
+				t.Fatalf("expected open without aad to fail")
+			}
+
+			// a sealed message opens with the same info and aad
+			sealed, err := x25519.SealHPKE(pkRm, skSm, info, aad, pt)
+			if err != nil {
+				t.Fatalf("failed to seal: %v", err)
+			}
+
+			if plain, err := x25519.OpenHPKE(skRm, pkSm, info, aad, sealed); err != nil || !bytes.Equal(plain, pt) {
+				t.Fatalf("failed to open: %v", err)
+			}
+		})
+	}
+}
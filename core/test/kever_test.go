@@ -0,0 +1,270 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func keverTestSign(t *testing.T, serder *cesr.SerderKERI, signers []*cesr.Signer) []*cesr.Siger {
+	t.Helper()
+
+	sigers := make([]*cesr.Siger, len(signers))
+	for i, signer := range signers {
+		//nolint:gosec
+		siger, err := signer.SignIndexed(serder.GetRaw(), false, types.Index(i), nil)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+
+		sigers[i] = siger
+	}
+
+	return sigers
+}
+
+func TestKever(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 3, true)
+	nextSigners, nextVerfers, nextDigers := eventingTestKeys(t, 2, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 2, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithSith("2"))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	if _, err := cesr.NewKever(icp, keverTestSign(t, icp, signers[:1])); err == nil {
+		t.Fatalf("expected insufficient signatures to be rejected")
+	}
+
+	// signatures by the wrong keys do not count
+	wrong := keverTestSign(t, icp, nextSigners)
+	if _, err := cesr.NewKever(icp, wrong); err == nil {
+		t.Fatalf("expected invalid signatures to be rejected")
+	}
+
+	// indices 0 and 1 signed by keys 1 and 2
+	if _, err := cesr.NewKever(icp, keverTestSign(t, icp, signers[1:])); err == nil {
+		t.Fatalf("expected misindexed signatures to be rejected")
+	}
+
+	kever, err := cesr.NewKever(icp, keverTestSign(t, icp, signers[:2]))
+	if err != nil {
+		t.Fatalf("failed to create kever: %v", err)
+	}
+
+	ixn, err := cesr.Interact(kever.State())
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if err := kever.Update(ixn, keverTestSign(t, ixn, signers[:2])); err != nil {
+		t.Fatalf("failed to accept interaction: %v", err)
+	}
+
+	if kever.State().Sn.Int64() != 1 || kever.State().EstSn.Int64() != 0 || kever.State().EstDig != types.Qb64(icp.Said()) {
+		t.Fatalf("unexpected state after interaction")
+	}
+
+	// replaying is not monotonic
+	if err := kever.Update(ixn, keverTestSign(t, ixn, signers[:2])); err == nil {
+		t.Fatalf("expected replayed interaction to be rejected")
+	}
+
+	rot, err := cesr.Rotate(kever.State(), nextVerfers, nextNextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	// rotations are signed by the new keys
+	if err := kever.Update(rot, keverTestSign(t, rot, signers[:2])); err == nil {
+		t.Fatalf("expected rotation signed by prior keys to be rejected")
+	}
+
+	if kever.State().Sn.Int64() != 1 {
+		t.Fatalf("state changed by rejected event")
+	}
+
+	if err := kever.Update(rot, keverTestSign(t, rot, nextSigners)); err != nil {
+		t.Fatalf("failed to accept rotation: %v", err)
+	}
+
+	state := kever.State()
+	if state.Sn.Int64() != 2 || state.EstDig != types.Qb64(rot.Said()) || len(state.Verfers) != 2 || len(state.NextDigers) != 2 {
+		t.Fatalf("unexpected state after rotation")
+	}
+
+	if kever.Serder().Said() != rot.Said() {
+		t.Fatalf("unexpected latest event")
+	}
+}
+
+func TestKeverEstablishmentOnly(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	_, _, nextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithTraits([]types.Trait{cesrgo.Trait_EstOnly}))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kever, err := cesr.NewKever(icp, keverTestSign(t, icp, signers))
+	if err != nil {
+		t.Fatalf("failed to create kever: %v", err)
+	}
+
+	// build the interaction as though the identifier were unrestricted
+	state := *kever.State()
+	state.Traits = nil
+
+	ixn, err := cesr.Interact(&state)
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if err := kever.Update(ixn, keverTestSign(t, ixn, signers)); err == nil {
+		t.Fatalf("expected interaction to be rejected")
+	}
+}
+
+func TestKeverNonTransferable(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, false)
+
+	icp, err := cesr.Incept(verfers, nil)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kever, err := cesr.NewKever(icp, keverTestSign(t, icp, signers))
+	if err != nil {
+		t.Fatalf("failed to create kever: %v", err)
+	}
+
+	state := *kever.State()
+	if _, err := cesr.Interact(&state); err == nil {
+		t.Fatalf("expected non-transferable interaction to be rejected")
+	}
+}
+
+func TestKeverRotationExposure(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	nextSigners, nextVerfers, nextDigers := eventingTestKeys(t, 1, true)
+	otherSigners, otherVerfers, _ := eventingTestKeys(t, 1, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kever, err := cesr.NewKever(icp, keverTestSign(t, icp, signers))
+	if err != nil {
+		t.Fatalf("failed to create kever: %v", err)
+	}
+
+	// the pre-committed key is listed, but only a key that was never committed to signs
+	keys := []*cesr.Verfer{nextVerfers[0], otherVerfers[0]}
+
+	rot, err := cesr.Rotate(kever.State(), keys, nextNextDigers, eopts.WithSith("1"))
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	siger, err := otherSigners[0].SignIndexed(rot.GetRaw(), false, 1, nil)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	if err := kever.Update(rot, []*cesr.Siger{siger}); err == nil {
+		t.Fatalf("expected rotation signed by an unexposed key to be rejected")
+	}
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(rot, []*cesr.Siger{siger}, nil); err == nil {
+		t.Fatalf("expected rotation signed by an unexposed key to be rejected")
+	}
+
+	// nor may such a rotation recover from an interaction
+	processed, _ := kevery.Kever(types.Qb64(icp.Pre()))
+
+	ixn, err := cesr.Interact(processed.State())
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), nil); err != nil {
+		t.Fatalf("failed to accept interaction: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(rot, []*cesr.Siger{siger}, nil); err == nil {
+		t.Fatalf("expected recovery signed by an unexposed key to be rejected")
+	}
+
+	if processed.State().Dig != types.Qb64(ixn.Said()) || len(kevery.Duplicities(types.Qb64(icp.Pre()))) != 0 {
+		t.Fatalf("unexpected state after rejected recovery")
+	}
+
+	// signed by the exposed key, the rotation is accepted
+	if err := kever.Update(rot, keverTestSign(t, rot, nextSigners)); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+}
+
+func TestKeverRotationTraits(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	nextSigners, nextVerfers, nextDigers := eventingTestKeys(t, 1, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithTraits([]types.Trait{cesrgo.Trait_EstOnly}))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	kever, err := cesr.NewKever(icp, keverTestSign(t, icp, signers))
+	if err != nil {
+		t.Fatalf("failed to create kever: %v", err)
+	}
+
+	_, built := cesr.Rotate(kever.State(), nextVerfers, nextNextDigers, eopts.WithTraits([]types.Trait{cesrgo.Trait_DoNotDelay}))
+	if built == nil {
+		t.Fatalf("expected rotation traits to be rejected")
+	}
+
+	rot, err := cesr.Rotate(kever.State(), nextVerfers, nextNextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	// a v2 rotation carrying traits, which would otherwise change the configuration
+	ked := rot.GetKed().Clone()
+	ked.Set("c", []any{string(cesrgo.Trait_DoNotDelay)})
+
+	forged, err := cesr.NewSerderKERI(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create rotation: %v", err)
+	}
+
+	// the builder and the validator apply the same rule
+	if _, err := kever.State().Apply(forged); err == nil || err.Error() != built.Error() {
+		t.Fatalf("expected rotation traits to be rejected: %v", err)
+	}
+
+	if err := kever.Update(forged, keverTestSign(t, forged, nextSigners)); err == nil {
+		t.Fatalf("expected rotation traits to be rejected")
+	}
+
+	if err := kever.Update(rot, keverTestSign(t, rot, nextSigners)); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	if !kever.State().EstOnly() || len(kever.State().Traits) != 1 {
+		t.Fatalf("expected inception traits to hold: %v", kever.State().Traits)
+	}
+}
@@ -0,0 +1,535 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	"github.com/jasoncolburne/cesrgo/core/counter/two"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func keveryTestMessage(t *testing.T, serder *cesr.SerderKERI, sigers, wigers []*cesr.Siger) []byte {
+	t.Helper()
+
+	stream := bytes.NewBuffer(slices.Clone(serder.GetRaw()))
+	for _, group := range []struct {
+		code   types.Code
+		sigers []*cesr.Siger
+	}{
+		{two.ControllerIdxSigs, sigers},
+		{two.WitnessIdxSigs, wigers},
+	} {
+		if len(group.sigers) == 0 {
+			continue
+		}
+
+		sigs := []byte{}
+		for _, siger := range group.sigers {
+			qb64b, err := siger.Qb64b()
+			if err != nil {
+				t.Fatalf("failed to get qb64b: %v", err)
+			}
+
+			sigs = append(sigs, qb64b...)
+		}
+
+		stream.Write(parserTestCounter(t, group.code, len(sigs)/4, false))
+		stream.Write(sigs)
+	}
+
+	return stream.Bytes()
+}
+
+func keveryTestWits(t *testing.T, count int) ([]*cesr.Signer, []types.Qb64) {
+	t.Helper()
+
+	signers, verfers, _ := eventingTestKeys(t, count, false)
+
+	wits := make([]types.Qb64, count)
+	for i, verfer := range verfers {
+		qb64, err := verfer.Qb64()
+		if err != nil {
+			t.Fatalf("failed to get qb64: %v", err)
+		}
+
+		wits[i] = qb64
+	}
+
+	return signers, wits
+}
+
+func keveryTestEscrowed(t *testing.T, err error, escrow cesr.Escrow) {
+	t.Helper()
+
+	var escrowed *cesr.EscrowError
+	if !errors.As(err, &escrowed) || escrowed.Escrow != escrow {
+		t.Fatalf("expected %s escrow, got %v", escrow, err)
+	}
+}
+
+func TestKeveryProcessesStream(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 1, true)
+	nextSigners, nextVerfers, nextDigers := eventingTestKeys(t, 1, true)
+	_, _, nextNextDigers := eventingTestKeys(t, 1, true)
+	witSigners, wits := keveryTestWits(t, 1)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithWits(wits))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	state, err := cesr.NewKeyState(icp)
+	if err != nil {
+		t.Fatalf("failed to create key state: %v", err)
+	}
+
+	ixn, err := cesr.Interact(state)
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	state, err = state.Apply(ixn)
+	if err != nil {
+		t.Fatalf("failed to apply: %v", err)
+	}
+
+	rot, err := cesr.Rotate(state, nextVerfers, nextNextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	stream := []byte{}
+	stream = append(stream, keveryTestMessage(t, icp, keverTestSign(t, icp, signers), keverTestSign(t, icp, witSigners))...)
+	stream = append(stream, keveryTestMessage(t, ixn, keverTestSign(t, ixn, signers), keverTestSign(t, ixn, witSigners))...)
+	stream = append(stream, keveryTestMessage(t, rot, keverTestSign(t, rot, nextSigners), keverTestSign(t, rot, witSigners))...)
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessStream(bytes.NewReader(stream)); err != nil {
+		t.Fatalf("failed to process stream: %v", err)
+	}
+
+	kever, ok := kevery.Kever(types.Qb64(icp.Pre()))
+	if !ok {
+		t.Fatalf("expected kever")
+	}
+
+	if kever.State().Sn.Int64() != 2 || kever.State().Dig != types.Qb64(rot.Said()) {
+		t.Fatalf("unexpected state: sn %s", kever.State().Sn.Text(16))
+	}
+
+	// replaying the stream is idempotent
+	if err := kevery.ProcessStream(bytes.NewReader(stream)); err != nil {
+		t.Fatalf("failed to replay stream: %v", err)
+	}
+
+	if len(kevery.Duplicities(types.Qb64(icp.Pre()))) != 0 {
+		t.Fatalf("unexpected duplicity")
+	}
+}
+
+func TestKeveryEscrows(t *testing.T) {
+	signers, verfers, _ := eventingTestKeys(t, 2, true)
+	_, _, nextDigers := eventingTestKeys(t, 2, true)
+	witSigners, wits := keveryTestWits(t, 2)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithSith("2"), eopts.WithWits(wits), eopts.WithToad(2))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	state, err := cesr.NewKeyState(icp)
+	if err != nil {
+		t.Fatalf("failed to create key state: %v", err)
+	}
+
+	ixn, err := cesr.Interact(state)
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	sigers := keverTestSign(t, icp, signers)
+	wigers := keverTestSign(t, icp, witSigners)
+
+	err = kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), keverTestSign(t, ixn, witSigners))
+	keveryTestEscrowed(t, err, cesr.Escrow_OutOfOrder)
+
+	err = kevery.ProcessEvent(icp, sigers[:1], nil)
+	keveryTestEscrowed(t, err, cesr.Escrow_PartiallySigned)
+
+	// signatures accumulate across arrivals
+	err = kevery.ProcessEvent(icp, sigers[1:], wigers[:1])
+	keveryTestEscrowed(t, err, cesr.Escrow_PartiallyWitnessed)
+
+	if len(kevery.Escrowed(cesr.Escrow_PartiallySigned)) != 0 || len(kevery.Escrowed(cesr.Escrow_PartiallyWitnessed)) != 1 {
+		t.Fatalf("unexpected escrows")
+	}
+
+	if err := kevery.ProcessEvent(icp, nil, wigers[1:]); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	// the interaction is released from escrow
+	kever, ok := kevery.Kever(types.Qb64(icp.Pre()))
+	if !ok || kever.State().Sn.Int64() != 1 {
+		t.Fatalf("expected escrowed interaction to be accepted")
+	}
+
+	for _, escrow := range cesr.ESCROWS {
+		if len(kevery.Escrowed(escrow)) != 0 {
+			t.Fatalf("unexpected %s escrow", escrow)
+		}
+	}
+}
+
+func TestKeveryDuplicateWitnessing(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	witSigners, wits := keveryTestWits(t, 3)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithWits(wits), eopts.WithToad(2))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	pre := types.Qb64(icp.Pre())
+	kevery := cesr.NewKevery()
+	wigers := keverTestSign(t, icp, witSigners)
+
+	// accepted once the threshold is met, with one witness still to sign
+	if err := kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), wigers[:2]); err != nil {
+		t.Fatalf("failed to accept inception: %v", err)
+	}
+
+	if count, toad, err := kevery.WitnessStatus(pre, 0); err != nil || count != 2 || toad != 2 {
+		t.Fatalf("unexpected witness status: %d of %d (%v)", count, toad, err)
+	}
+
+	// a signature by a witness over another event is not merged
+	_, _, otherDigers := eventingTestKeys(t, 1, true)
+	other, err := cesr.Incept(verfers, otherDigers, eopts.WithWits(wits), eopts.WithToad(2))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	forged := keverTestSign(t, other, witSigners)
+	if err := kevery.ProcessStream(bytes.NewReader(keveryTestMessage(t, icp, nil, forged[2:]))); err != nil {
+		t.Fatalf("failed to process duplicate: %v", err)
+	}
+
+	if count, _, _ := kevery.WitnessStatus(pre, 0); count != 2 {
+		t.Fatalf("expected invalid witness signature to be ignored: %d", count)
+	}
+
+	// the duplicate completes the witnessing of the accepted event
+	if err := kevery.ProcessStream(bytes.NewReader(keveryTestMessage(t, icp, nil, wigers[2:]))); err != nil {
+		t.Fatalf("failed to process duplicate: %v", err)
+	}
+
+	count, _, err := kevery.WitnessStatus(pre, 0)
+	if err != nil || count != len(wits) || !kevery.FullyWitnessed(pre, 0) {
+		t.Fatalf("expected fully witnessed event: %d of %d (%v)", count, len(wits), err)
+	}
+
+	kever, _ := kevery.Kever(pre)
+	event, _ := kever.FirstSeen(0)
+	if len(event.Wigers) != len(wits) {
+		t.Fatalf("expected merged witness signatures in the first seen log: %d", len(event.Wigers))
+	}
+}
+
+func keveryTestAnchor(t *testing.T, kevery *cesr.Kevery, pre string, signers []*cesr.Signer, serder *cesr.SerderKERI) *cesr.SerderKERI {
+	t.Helper()
+
+	kever, ok := kevery.Kever(types.Qb64(pre))
+	if !ok {
+		t.Fatalf("unknown prefix: %s", pre)
+	}
+
+	seal, err := cesr.EventSeal(serder)
+	if err != nil {
+		t.Fatalf("failed to create seal: %v", err)
+	}
+
+	ixn, err := cesr.Interact(kever.State(), eopts.WithData([]types.Map{seal}))
+	if err != nil {
+		t.Fatalf("failed to interact: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(ixn, keverTestSign(t, ixn, signers), nil); err != nil {
+		t.Fatalf("failed to accept anchor: %v", err)
+	}
+
+	return ixn
+}
+
+func TestKeveryDelegation(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	delegateSigners, delegateVerfers, _ := eventingTestKeys(t, 1, true)
+	delegateNextSigners, delegateNextVerfers, delegateNextDigers := eventingTestKeys(t, 1, true)
+	_, _, delegateNextNextDigers := eventingTestKeys(t, 1, true)
+
+	delegator, err := cesr.Incept(verfers, nextDigers, eopts.WithCode(codex.Blake3_256))
+	if err != nil {
+		t.Fatalf("failed to incept delegator: %v", err)
+	}
+
+	dip, err := cesr.Incept(delegateVerfers, delegateNextDigers, eopts.WithDelegator(types.Qb64(delegator.Pre())))
+	if err != nil {
+		t.Fatalf("failed to incept delegate: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+
+	err = kevery.ProcessEvent(dip, keverTestSign(t, dip, delegateSigners), nil)
+	keveryTestEscrowed(t, err, cesr.Escrow_MissingDelegator)
+
+	if err := kevery.ProcessEvent(delegator, keverTestSign(t, delegator, signers), nil); err != nil {
+		t.Fatalf("failed to accept delegator: %v", err)
+	}
+
+	if len(kevery.Escrowed(cesr.Escrow_PartiallyDelegated)) != 1 {
+		t.Fatalf("expected delegated inception to await approval")
+	}
+
+	keveryTestAnchor(t, kevery, delegator.Pre(), signers, dip)
+
+	delegate, ok := kevery.Kever(types.Qb64(dip.Pre()))
+	if !ok {
+		t.Fatalf("expected delegated inception to be accepted")
+	}
+
+	if source := delegate.Serder(); source.Said() != dip.Said() {
+		t.Fatalf("unexpected latest event")
+	}
+
+	drt, err := cesr.Rotate(delegate.State(), delegateNextVerfers, delegateNextNextDigers)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	// a source that does not anchor the rotation is rejected
+	delegatorKever, _ := kevery.Kever(types.Qb64(delegator.Pre()))
+	wrong, _ := delegatorKever.Event(1)
+
+	one := big.NewInt(1)
+	seqner, err := cesr.NewSeqner(one, nil)
+	if err != nil {
+		t.Fatalf("failed to create seqner: %v", err)
+	}
+
+	saider, err := cesr.NewSaider(nil, nil, nil, options.WithQb64(types.Qb64(wrong.Serder.Said())))
+	if err != nil {
+		t.Fatalf("failed to create saider: %v", err)
+	}
+
+	err = kevery.ProcessSignedEvent(&cesr.SignedEvent{
+		Serder: drt,
+		Sigers: keverTestSign(t, drt, delegateNextSigners),
+		Source: &cesr.SealSourceCouple{Seqner: seqner, Saider: saider},
+	})
+
+	var escrowed *cesr.EscrowError
+	if err == nil || errors.As(err, &escrowed) {
+		t.Fatalf("expected source that does not anchor to be rejected: %v", err)
+	}
+
+	// without a source, the rotation awaits approval
+	keveryTestEscrowed(t, kevery.ProcessEvent(drt, keverTestSign(t, drt, delegateNextSigners), nil), cesr.Escrow_PartiallyDelegated)
+
+	anchor := keveryTestAnchor(t, kevery, delegator.Pre(), signers, drt)
+
+	if delegate.State().Dig != types.Qb64(drt.Said()) {
+		t.Fatalf("expected delegated rotation to be accepted")
+	}
+
+	// the accepted rotation carries its source, so it replays with its approval
+	event, _ := delegate.Event(1)
+	if event.Source == nil {
+		t.Fatalf("expected source seal")
+	}
+
+	said, err := event.Source.Saider.Qb64()
+	if err != nil || string(said) != anchor.Said() {
+		t.Fatalf("unexpected source: %s", said)
+	}
+
+	replay := cesr.NewKevery()
+	for _, pre := range []string{delegator.Pre(), dip.Pre()} {
+		kever, _ := kevery.Kever(types.Qb64(pre))
+		for sn := range uint64(3) {
+			event, ok := kever.Event(sn)
+			if !ok {
+				continue
+			}
+
+			msg, err := event.Messagize()
+			if err != nil {
+				t.Fatalf("failed to messagize: %v", err)
+			}
+
+			if err := replay.ProcessStream(bytes.NewReader(msg)); err != nil {
+				t.Fatalf("failed to replay: %v", err)
+			}
+		}
+	}
+
+	replayed, ok := replay.Kever(types.Qb64(dip.Pre()))
+	if !ok || replayed.State().Dig != types.Qb64(drt.Said()) {
+		t.Fatalf("expected replayed delegated log")
+	}
+}
+
+func TestKeveryDelegationSourceTriple(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	delegateSigners, delegateVerfers, delegateNextDigers := eventingTestKeys(t, 1, true)
+
+	delegator, err := cesr.Incept(verfers, nextDigers, eopts.WithCode(codex.Blake3_256))
+	if err != nil {
+		t.Fatalf("failed to incept delegator: %v", err)
+	}
+
+	dip, err := cesr.Incept(delegateVerfers, delegateNextDigers, eopts.WithDelegator(types.Qb64(delegator.Pre())))
+	if err != nil {
+		t.Fatalf("failed to incept delegate: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessEvent(delegator, keverTestSign(t, delegator, signers), nil); err != nil {
+		t.Fatalf("failed to accept delegator: %v", err)
+	}
+
+	anchor := keveryTestAnchor(t, kevery, delegator.Pre(), signers, dip)
+
+	message := func(pre string) []byte {
+		msg := keveryTestMessage(t, dip, keverTestSign(t, dip, delegateSigners), nil)
+
+		seqner, err := cesr.NewSeqner(big.NewInt(1), nil)
+		if err != nil {
+			t.Fatalf("failed to create seqner: %v", err)
+		}
+
+		sn, err := seqner.Qb64b()
+		if err != nil {
+			t.Fatalf("failed to get qb64b: %v", err)
+		}
+
+		triple := slices.Concat([]byte(pre), sn, []byte(anchor.Said()))
+
+		msg = append(msg, parserTestCounter(t, two.SealSourceTriples, len(triple)/4, false)...)
+		return append(msg, triple...)
+	}
+
+	// a triple naming another prefix is rejected rather than escrowed
+	err = kevery.ProcessStream(bytes.NewReader(message(dip.Pre())))
+	if err == nil {
+		t.Fatalf("expected source triple naming the delegate to be rejected")
+	}
+
+	if _, ok := kevery.Kever(types.Qb64(dip.Pre())); ok || len(kevery.Escrowed(cesr.Escrow_PartiallyDelegated)) != 0 {
+		t.Fatalf("unexpected acceptance or escrow")
+	}
+
+	if err := kevery.ProcessStream(bytes.NewReader(message(delegator.Pre()))); err != nil {
+		t.Fatalf("failed to process delegated inception: %v", err)
+	}
+
+	delegate, ok := kevery.Kever(types.Qb64(dip.Pre()))
+	if !ok {
+		t.Fatalf("expected delegated inception to be accepted")
+	}
+
+	event, _ := delegate.Event(0)
+	if said, err := event.Source.Saider.Qb64(); err != nil || string(said) != anchor.Said() {
+		t.Fatalf("unexpected source: %s", said)
+	}
+}
+
+func TestKeveryDelegationTraits(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	delegateSigners, delegateVerfers, _ := eventingTestKeys(t, 1, true)
+	delegateNextSigners, delegateNextVerfers, delegateNextDigers := eventingTestKeys(t, 1, true)
+
+	kevery := cesr.NewKevery()
+
+	nondelegator, err := cesr.Incept(
+		verfers,
+		nextDigers,
+		eopts.WithCode(codex.Blake3_256),
+		eopts.WithTraits([]types.Trait{cesrgo.Trait_DoNotDelay}),
+	)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(nondelegator, keverTestSign(t, nondelegator, signers), nil); err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+
+	dip, err := cesr.Incept(delegateVerfers, delegateNextDigers, eopts.WithDelegator(types.Qb64(nondelegator.Pre())))
+	if err != nil {
+		t.Fatalf("failed to incept delegate: %v", err)
+	}
+
+	err = kevery.ProcessEvent(dip, keverTestSign(t, dip, delegateSigners), nil)
+
+	var escrowed *cesr.EscrowError
+	if err == nil || errors.As(err, &escrowed) {
+		t.Fatalf("expected delegation by non-delegator to be rejected: %v", err)
+	}
+
+	// a delegate claiming to be its own delegator still needs approval
+	otherSigners, otherVerfers, otherNextDigers := eventingTestKeys(t, 1, true)
+
+	delegator, err := cesr.Incept(otherVerfers, otherNextDigers, eopts.WithCode(codex.Blake3_256))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	if err := kevery.ProcessEvent(delegator, keverTestSign(t, delegator, otherSigners), nil); err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+
+	dip, err = cesr.Incept(
+		delegateVerfers,
+		delegateNextDigers,
+		eopts.WithDelegator(types.Qb64(delegator.Pre())),
+		eopts.WithTraits([]types.Trait{cesrgo.Trait_DelegateIsDelegator}),
+	)
+	if err != nil {
+		t.Fatalf("failed to incept delegate: %v", err)
+	}
+
+	keveryTestEscrowed(t, kevery.ProcessEvent(dip, keverTestSign(t, dip, delegateSigners), nil), cesr.Escrow_PartiallyDelegated)
+	keveryTestAnchor(t, kevery, delegator.Pre(), otherSigners, dip)
+
+	delegate, ok := kevery.Kever(types.Qb64(dip.Pre()))
+	if !ok {
+		t.Fatalf("expected delegated inception to be accepted")
+	}
+
+	drt, err := cesr.Rotate(delegate.State(), delegateNextVerfers, nil)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	err = kevery.ProcessEvent(drt, keverTestSign(t, drt, delegateNextSigners), nil)
+	keveryTestEscrowed(t, err, cesr.Escrow_PartiallyDelegated)
+
+	if delegate.State().Sn.Sign() != 0 {
+		t.Fatalf("expected unanchored rotation to be held")
+	}
+
+	keveryTestAnchor(t, kevery, delegator.Pre(), otherSigners, drt)
+
+	if delegate.State().Dig != types.Qb64(drt.Said()) {
+		t.Fatalf("expected anchored rotation to be accepted")
+	}
+}
@@ -1,9 +1,11 @@
 package test
 
 import (
+	"errors"
 	"slices"
 	"testing"
 
+	"github.com/jasoncolburne/cesrgo/common"
 	cesr "github.com/jasoncolburne/cesrgo/core"
 	codex "github.com/jasoncolburne/cesrgo/core/matter"
 	"github.com/jasoncolburne/cesrgo/core/matter/options"
@@ -167,3 +169,34 @@ func TestMatterRoundTrip(t *testing.T) {
 		t.Fatalf("raw mismatch after round trip: %x != %x", raw, qb64bMatter.GetRaw())
 	}
 }
+
+func TestMatterShortage(t *testing.T) {
+	full := &cesr.UndifferentiatedMatter{}
+	raw := types.Raw("\xeaz5\x17\xfeQ!yʹH\x9b\x1aօXO\x1a\x1aq\x17\xd7r_$9\xfaҺණ")
+
+	if err := cesr.NewMatter(full, options.WithCode(codex.Blake3_256), options.WithRaw(raw)); err != nil {
+		t.Fatalf("failed to create matter: %v", err)
+	}
+
+	qb64b, err := full.Qb64b()
+	if err != nil {
+		t.Fatalf("failed to get qb64b: %v", err)
+	}
+
+	qb2, err := full.Qb2()
+	if err != nil {
+		t.Fatalf("failed to get qb2: %v", err)
+	}
+
+	var shortage *common.ShortageError
+
+	err = cesr.NewMatter(&cesr.UndifferentiatedMatter{}, options.WithQb64b(qb64b[:len(qb64b)-5]))
+	if !errors.As(err, &shortage) || shortage.Need != 5 {
+		t.Fatalf("expected shortage of 5, got %v", err)
+	}
+
+	err = cesr.NewMatter(&cesr.UndifferentiatedMatter{}, options.WithQb2(qb2[:len(qb2)-4]))
+	if !errors.As(err, &shortage) || shortage.Need != 4 {
+		t.Fatalf("expected shortage of 4, got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func nativeTestInception(t *testing.T) types.Map {
+	t.Helper()
+
+	signer, err := cesr.NewSigner(true)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	key, err := signer.GetVerfer().Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	diger, err := cesr.NewDiger([]byte(key), options.WithCode(codex.Blake3_256))
+	if err != nil {
+		t.Fatalf("failed to create diger: %v", err)
+	}
+
+	next, err := diger.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAACESRAAAA.")
+	ked.Set("t", "icp")
+	ked.Set("d", "")
+	ked.Set("i", string(key))
+	ked.Set("s", "0")
+	ked.Set("kt", "1")
+	ked.Set("k", []any{string(key)})
+	ked.Set("nt", []any{"1/2", "1/2"})
+	ked.Set("n", []any{string(next), string(next)})
+	ked.Set("bt", "0")
+	ked.Set("b", []any{})
+	ked.Set("c", []any{"EO"})
+	ked.Set("a", []any{})
+
+	return ked
+}
+
+func TestNativeFixedBodyRoundTrip(t *testing.T) {
+	ked := nativeTestInception(t)
+
+	kind := cesrgo.Kind_CESR
+	sadder, err := cesr.NewSadder(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	raw := sadder.GetRaw()
+	if !bytes.HasPrefix(raw, []byte("-F")) {
+		t.Fatalf("expected fixed body group, got %s", raw[:4])
+	}
+
+	v, _ := sadder.GetKed().Get("v")
+	_, _, vKind, size, _, err := common.Deversify(v.(string))
+	if err != nil {
+		t.Fatalf("failed to deversify: %v", err)
+	}
+
+	if vKind != cesrgo.Kind_CESR || int(size) != len(raw) {
+		t.Fatalf("unexpected version string: %v", v)
+	}
+
+	loaded, err := cesr.NewSadder(nil, &raw, nil, nil, false)
+	if err != nil {
+		t.Fatalf("failed to load sadder: %v", err)
+	}
+
+	if loaded.GetKind() != cesrgo.Kind_CESR {
+		t.Fatalf("kind mismatch: %s", loaded.GetKind())
+	}
+
+	expected, err := json.Marshal(sadder.GetKed())
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	actual, err := json.Marshal(loaded.GetKed())
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("ked mismatch: %s != %s", actual, expected)
+	}
+
+	loadedKed := loaded.GetKed()
+	saider, err := cesr.NewSaider(&loadedKed, nil, &kind)
+	if err != nil {
+		t.Fatalf("failed to create saider: %v", err)
+	}
+
+	said, err := saider.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	d, _ := loadedKed.Get("d")
+	if string(said) != d {
+		t.Fatalf("said mismatch: %s != %s", said, d)
+	}
+}
+
+func TestNativeMapBodyRoundTrip(t *testing.T) {
+	seal := types.NewMap()
+	seal.Set("i", "EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU")
+	seal.Set("s", "a")
+
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAACESRAAAA.")
+	ked.Set("t", "rpy")
+	ked.Set("d", "")
+	ked.Set("dt", "2020-08-22T17:50:09.988921+00:00")
+	ked.Set("r", "not a primitive")
+	ked.Set("u", "")
+	ked.Set("a", []any{seal})
+	ked.Set("e", true)
+
+	raw, err := cesr.MarshalNative(ked)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if !bytes.HasPrefix(raw, []byte("-G")) {
+		t.Fatalf("expected map body group, got %s", raw[:4])
+	}
+
+	proto, pvrsn, size, _, err := cesr.SmellNative(raw)
+	if err != nil {
+		t.Fatalf("failed to smell: %v", err)
+	}
+
+	if proto != cesrgo.Proto_KERI || pvrsn != cesrgo.VERSION_2_0 || int(size) != len(raw) {
+		t.Fatalf("unexpected smell: %s %v %d", proto, pvrsn, size)
+	}
+
+	loaded, err := cesr.UnmarshalNative(raw)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	// the loaded version string carries the size
+	ked.Set("v", "KERICAACAACESRAAC8.")
+
+	expected, err := json.Marshal(ked)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	actual, err := json.Marshal(loaded)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("ked mismatch: %s != %s", actual, expected)
+	}
+}
+
+// TestNativeTextRoundTrip checks that text which happens to parse as qb64 stays text outside of the fields
+// that hold primitives
+func TestNativeTextRoundTrip(t *testing.T) {
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAACESRAAAA.")
+	ked.Set("t", "rpy")
+	ked.Set("d", "")
+	ked.Set("i", "EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU")
+	ked.Set("note", "MAAA")
+	ked.Set("tags", []any{"MAAA", "EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU"})
+
+	raw, err := cesr.MarshalNative(ked)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if bytes.Count(raw, []byte("EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU")) != 1 {
+		t.Fatalf("expected only the prefix as a bare primitive: %s", raw)
+	}
+
+	loaded, err := cesr.UnmarshalNative(raw)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	for _, label := range []string{"i", "note", "tags"} {
+		expected, _ := ked.Get(label)
+		actual, _ := loaded.Get(label)
+
+		expectedJSON, err := json.Marshal(expected)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+
+		actualJSON, err := json.Marshal(actual)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+
+		if !bytes.Equal(expectedJSON, actualJSON) {
+			t.Fatalf("%s mismatch: %s != %s", label, actualJSON, expectedJSON)
+		}
+	}
+}
+
+func TestNativeMessagesParse(t *testing.T) {
+	ked := nativeTestInception(t)
+
+	kind := cesrgo.Kind_CESR
+	sadder, err := cesr.NewSadder(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	stream := append(append([]byte{}, sadder.GetRaw()...), sadder.GetRaw()...)
+
+	msgs, err := cesr.NewParser(bytes.NewReader(stream)).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+
+	for _, msg := range msgs {
+		if msg.Kind != cesrgo.Kind_CESR || !bytes.Equal(msg.Raw, sadder.GetRaw()) {
+			t.Fatalf("unexpected message: %s", msg.Raw)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	"github.com/jasoncolburne/cesrgo/core/counter/one"
+	coptions "github.com/jasoncolburne/cesrgo/core/counter/options"
+	"github.com/jasoncolburne/cesrgo/core/counter/two"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func parserTestMessage(t *testing.T) (*cesr.Sadder, *cesr.Signer, *cesr.Signer) {
+	t.Helper()
+
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAAJSONAAAA.")
+	ked.Set("d", "")
+
+	sadder, err := cesr.NewSadder(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	signer, err := cesr.NewSigner(true)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	witness, err := cesr.NewSigner(false)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	return sadder, signer, witness
+}
+
+func parserTestCounter(t *testing.T, code types.Code, count int, binary bool) []byte {
+	t.Helper()
+
+	counter, err := cesr.NewCounter(coptions.WithCode(code), coptions.WithCount(types.Count(count)))
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	if binary {
+		qb2, err := counter.Qb2()
+		if err != nil {
+			t.Fatalf("failed to get qb2: %v", err)
+		}
+
+		return qb2
+	}
+
+	qb64b, err := counter.Qb64b()
+	if err != nil {
+		t.Fatalf("failed to get qb64b: %v", err)
+	}
+
+	return qb64b
+}
+
+func parserTestStream(t *testing.T, binary bool) ([]byte, types.Raw) {
+	t.Helper()
+
+	sadder, signer, witness := parserTestMessage(t)
+	ser := sadder.GetRaw()
+
+	siger, err := signer.SignIndexed(ser, false, 0, nil)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	cigar, err := witness.SignUnindexed(ser)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	encode := func(q interface {
+		Qb2() (types.Qb2, error)
+		Qb64b() (types.Qb64b, error)
+	}) []byte {
+		if binary {
+			qb2, err := q.Qb2()
+			if err != nil {
+				t.Fatalf("failed to get qb2: %v", err)
+			}
+
+			return qb2
+		}
+
+		qb64b, err := q.Qb64b()
+		if err != nil {
+			t.Fatalf("failed to get qb64b: %v", err)
+		}
+
+		return qb64b
+	}
+
+	unit := 4
+	if binary {
+		unit = 3
+	}
+
+	sigs := encode(siger)
+	couple := append(encode(witness.GetVerfer()), encode(cigar)...)
+
+	stream := bytes.Buffer{}
+	stream.Write(ser)
+	stream.Write(parserTestCounter(t, two.ControllerIdxSigs, len(sigs)/unit, binary))
+	stream.Write(sigs)
+	stream.Write(parserTestCounter(t, two.NonTransReceiptCouples, len(couple)/unit, binary))
+	stream.Write(couple)
+
+	return stream.Bytes(), ser
+}
+
+func TestParserExtractsMessagesAndAttachments(t *testing.T) {
+	for _, binary := range []bool{false, true} {
+		stream, ser := parserTestStream(t, binary)
+
+		// two copies of the same message, to exercise message boundaries
+		doubled := append(append([]byte{}, stream...), stream...)
+
+		parser := cesr.NewParser(bytes.NewReader(doubled))
+		msgs, err := parser.ParseAll()
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+
+		if len(msgs) != 2 {
+			t.Fatalf("expected 2 messages, got %d", len(msgs))
+		}
+
+		for _, msg := range msgs {
+			if !bytes.Equal(msg.Raw, ser) {
+				t.Fatalf("raw mismatch: %s != %s", msg.Raw, ser)
+			}
+
+			if len(msg.ControllerIdxSigs) != 1 {
+				t.Fatalf("expected 1 controller signature, got %d", len(msg.ControllerIdxSigs))
+			}
+
+			if len(msg.NonTransReceiptCouples) != 1 {
+				t.Fatalf("expected 1 receipt couple, got %d", len(msg.NonTransReceiptCouples))
+			}
+
+			couple := msg.NonTransReceiptCouples[0]
+			verified, err := couple.Verfer.Verify(couple.Cigar.GetRaw(), msg.Raw)
+			if err != nil || !verified {
+				t.Fatalf("failed to verify receipt couple: %v", err)
+			}
+
+			sadder, err := msg.Sadder()
+			if err != nil {
+				t.Fatalf("failed to load sadder: %v", err)
+			}
+
+			if _, ok := sadder.GetKed().Get("d"); !ok {
+				t.Fatalf("d not found in parsed message")
+			}
+		}
+	}
+}
+
+func TestParserFlattensBodyWithAttachmentGroups(t *testing.T) {
+	stream, ser := parserTestStream(t, false)
+
+	wrapped := parserTestCounter(t, two.BodyWithAttachmentGroup, len(stream)/4, false)
+	wrapped = append(wrapped, stream...)
+
+	msgs, err := cesr.NewParser(bytes.NewReader(wrapped)).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if len(msgs) != 1 || !bytes.Equal(msgs[0].Raw, ser) {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+}
+
+func TestParserRejectsOpCodes(t *testing.T) {
+	_, err := cesr.NewParser(bytes.NewReader([]byte("_AAA"))).ParseAll()
+	if err == nil {
+		t.Fatalf("expected error for op code")
+	}
+}
+
+func TestParserResumesAfterShortage(t *testing.T) {
+	for _, binary := range []bool{false, true} {
+		stream, ser := parserTestStream(t, binary)
+
+		parser := cesr.NewParser(nil)
+		msgs := []*cesr.Message{}
+
+		// feed the stream a few bytes at a time, as a network reader would
+		for offset := 0; offset < len(stream); offset += 7 {
+			parser.Feed(stream[offset:min(offset+7, len(stream))])
+
+			msg, err := parser.Next()
+			var shortage *common.ShortageError
+			if errors.As(err, &shortage) {
+				if shortage.Need < 1 {
+					t.Fatalf("expected positive shortage, got %d", shortage.Need)
+				}
+
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			msgs = append(msgs, msg)
+		}
+
+		// the message may still be awaiting attachments until the stream is closed
+		parser.Close()
+
+		rest, err := parser.ParseAll()
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+
+		msgs = append(msgs, rest...)
+		if len(msgs) != 1 {
+			t.Fatalf("expected 1 message, got %d", len(msgs))
+		}
+
+		if !bytes.Equal(msgs[0].Raw, ser) {
+			t.Fatalf("raw mismatch: %s != %s", msgs[0].Raw, ser)
+		}
+
+		if len(msgs[0].ControllerIdxSigs) != 1 || len(msgs[0].NonTransReceiptCouples) != 1 {
+			t.Fatalf("attachments not fully parsed")
+		}
+	}
+}
+
+func TestParserReadsIncrementally(t *testing.T) {
+	stream, _ := parserTestStream(t, false)
+
+	msgs, err := cesr.NewParser(iotest.OneByteReader(bytes.NewReader(stream))).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if len(msgs) != 1 || len(msgs[0].NonTransReceiptCouples) != 1 {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+}
+
+func TestParserReportsTruncatedStream(t *testing.T) {
+	stream, _ := parserTestStream(t, false)
+
+	parser := cesr.NewParser(bytes.NewReader(stream[:len(stream)-3]))
+	_, err := parser.Next()
+
+	var shortage *common.ShortageError
+	if !errors.As(err, &shortage) {
+		t.Fatalf("expected shortage, got %v", err)
+	}
+
+	if shortage.Need != 3 {
+		t.Fatalf("expected shortage of 3, got %d", shortage.Need)
+	}
+
+	if _, err := cesr.NewParser(bytes.NewReader(nil)).Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected eof, got %v", err)
+	}
+}
+
+func TestParserHandlesV1StreamsAndGenusSwitching(t *testing.T) {
+	ked := types.NewMap()
+	ked.Set("v", "KERI10JSON000000_")
+	ked.Set("d", "")
+
+	sadder, err := cesr.NewSadder(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	signer, err := cesr.NewSigner(true)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	v1 := sadder.GetRaw()
+	siger, err := signer.SignIndexed(v1, false, 0, nil)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	sig, err := siger.Qb64b()
+	if err != nil {
+		t.Fatalf("failed to get qb64b: %v", err)
+	}
+
+	v1Counter := func(code types.Code, count int) []byte {
+		counter, err := cesr.NewCounter(
+			coptions.WithCode(code),
+			coptions.WithCount(types.Count(count)),
+			coptions.WithVersion(cesrgo.VERSION_1_0),
+		)
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		qb64b, err := counter.Qb64b()
+		if err != nil {
+			t.Fatalf("failed to get qb64b: %v", err)
+		}
+
+		return qb64b
+	}
+
+	genusCount, err := cesr.GenusVersionCount(cesrgo.VERSION_1_0)
+	if err != nil {
+		t.Fatalf("failed to encode genus version: %v", err)
+	}
+
+	v2Stream, v2 := parserTestStream(t, false)
+
+	stream := bytes.Buffer{}
+	// a v1 message counts signatures rather than quadlets
+	stream.Write(v1)
+	stream.Write(v1Counter(one.ControllerIdxSigs, 2))
+	stream.Write(sig)
+	stream.Write(sig)
+	// a v2 message, followed by a switch to v1 for a further attachment
+	stream.Write(v2Stream)
+	stream.Write(parserTestCounter(t, two.KERIACDCGenusVersion, int(genusCount), false))
+	stream.Write(v1Counter(one.ControllerIdxSigs, 1))
+	stream.Write(sig)
+
+	msgs, err := cesr.NewParser(bytes.NewReader(stream.Bytes())).ParseAll()
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if len(msgs) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+
+	if !bytes.Equal(msgs[0].Raw, v1) || msgs[0].Version != cesrgo.VERSION_1_0 || len(msgs[0].ControllerIdxSigs) != 2 {
+		t.Fatalf("unexpected v1 message: %s, %d signatures", msgs[0].Raw, len(msgs[0].ControllerIdxSigs))
+	}
+
+	if !bytes.Equal(msgs[1].Raw, v2) || len(msgs[1].ControllerIdxSigs) != 2 || len(msgs[1].NonTransReceiptCouples) != 1 {
+		t.Fatalf("unexpected v2 message: %s, %d signatures", msgs[1].Raw, len(msgs[1].ControllerIdxSigs))
+	}
+}
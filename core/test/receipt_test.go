@@ -0,0 +1,200 @@
+package test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	eopts "github.com/jasoncolburne/cesrgo/core/eventing/options"
+	"github.com/jasoncolburne/cesrgo/core/matter/options"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func receiptTestWitnessReceipt(t *testing.T, serder *cesr.SerderKERI, witness *cesr.Signer) []byte {
+	t.Helper()
+
+	rct, err := cesr.Receipt(serder)
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	cigar, err := witness.SignUnindexed(serder.GetRaw())
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	msg, err := (&cesr.SignedEvent{Serder: rct, Cigars: []*cesr.Cigar{cigar}}).Messagize()
+	if err != nil {
+		t.Fatalf("failed to messagize: %v", err)
+	}
+
+	return msg
+}
+
+func TestReceiptBuilder(t *testing.T) {
+	_, verfers, nextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithKind(cesrgo.Kind_CBOR))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	rct, err := cesr.Receipt(icp)
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	if rct.Ilk() != cesrgo.Ilk_RCT || rct.Said() != icp.Said() || rct.Pre() != icp.Pre() || rct.GetKind() != cesrgo.Kind_CBOR {
+		t.Fatalf("unexpected receipt: %v", rct.GetKed())
+	}
+
+	raw := rct.GetRaw()
+	if _, err := cesr.NewSerderKERI(nil, &raw, nil, nil, true); err != nil {
+		t.Fatalf("failed to load receipt: %v", err)
+	}
+}
+
+func TestReceiptsWitnessEvents(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	witSigners, wits := keveryTestWits(t, 2)
+
+	icp, err := cesr.Incept(verfers, nextDigers, eopts.WithWits(wits))
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	pre := types.Qb64(icp.Pre())
+	kevery := cesr.NewKevery()
+
+	// a receipt that arrives first waits for its event
+	first := receiptTestWitnessReceipt(t, icp, witSigners[0])
+	if err := kevery.ProcessStream(bytes.NewReader(first)); err != nil {
+		t.Fatalf("failed to process receipt: %v", err)
+	}
+
+	if len(kevery.Escrowed(cesr.Escrow_UnverifiedReceipt)) != 1 {
+		t.Fatalf("expected escrowed receipt")
+	}
+
+	err = kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil)
+	keveryTestEscrowed(t, err, cesr.Escrow_PartiallyWitnessed)
+
+	if len(kevery.Escrowed(cesr.Escrow_UnverifiedReceipt)) != 0 {
+		t.Fatalf("expected escrowed receipt to be applied")
+	}
+
+	// a receipt whose signature does not verify is rejected
+	rct, err := cesr.Receipt(icp)
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	cigar, err := witSigners[1].SignUnindexed(rct.GetRaw())
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	if err := kevery.ProcessSignedEvent(&cesr.SignedEvent{Serder: rct, Cigars: []*cesr.Cigar{cigar}}); err == nil {
+		t.Fatalf("expected invalid receipt to be rejected")
+	}
+
+	if kevery.FullyWitnessed(pre, 0) {
+		t.Fatalf("unexpected fully witnessed event")
+	}
+
+	second := receiptTestWitnessReceipt(t, icp, witSigners[1])
+	if err := kevery.ProcessStream(bytes.NewReader(second)); err != nil {
+		t.Fatalf("failed to process receipt: %v", err)
+	}
+
+	if !kevery.FullyWitnessed(pre, 0) {
+		t.Fatalf("expected fully witnessed event")
+	}
+
+	count, toad, err := kevery.WitnessStatus(pre, 0)
+	if err != nil || count != 2 || toad != 2 {
+		t.Fatalf("unexpected witness status: %d of %d (%v)", count, toad, err)
+	}
+}
+
+func TestReceiptsByValidators(t *testing.T) {
+	signers, verfers, nextDigers := eventingTestKeys(t, 1, true)
+	validatorSigners, validatorVerfers, validatorNextDigers := eventingTestKeys(t, 1, true)
+
+	icp, err := cesr.Incept(verfers, nextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept: %v", err)
+	}
+
+	validator, err := cesr.Incept(validatorVerfers, validatorNextDigers)
+	if err != nil {
+		t.Fatalf("failed to incept validator: %v", err)
+	}
+
+	kevery := cesr.NewKevery()
+	if err := kevery.ProcessEvent(icp, keverTestSign(t, icp, signers), nil); err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+
+	rct, err := cesr.Receipt(icp)
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	prefixer, err := validator.Prefixer()
+	if err != nil {
+		t.Fatalf("failed to get prefixer: %v", err)
+	}
+
+	seqner, err := cesr.NewSeqner(big.NewInt(0), nil)
+	if err != nil {
+		t.Fatalf("failed to create seqner: %v", err)
+	}
+
+	saider, err := cesr.NewSaider(nil, nil, nil, options.WithQb64(types.Qb64(validator.Said())))
+	if err != nil {
+		t.Fatalf("failed to create saider: %v", err)
+	}
+
+	receipt := &cesr.SignedEvent{
+		Serder: rct,
+		Vrcs: []cesr.TransIdxSigGroup{{
+			Prefixer: prefixer,
+			Seqner:   seqner,
+			Saider:   saider,
+			Sigers:   keverTestSign(t, icp, validatorSigners),
+		}},
+	}
+
+	msg, err := receipt.Messagize()
+	if err != nil {
+		t.Fatalf("failed to messagize: %v", err)
+	}
+
+	// the validator's key state is not yet known
+	if err := kevery.ProcessStream(bytes.NewReader(msg)); err != nil {
+		t.Fatalf("failed to process receipt: %v", err)
+	}
+
+	if len(kevery.Escrowed(cesr.Escrow_UnverifiedReceipt)) != 1 {
+		t.Fatalf("expected escrowed receipt")
+	}
+
+	if err := kevery.ProcessEvent(validator, keverTestSign(t, validator, validatorSigners), nil); err != nil {
+		t.Fatalf("failed to accept validator: %v", err)
+	}
+
+	kever, _ := kevery.Kever(types.Qb64(icp.Pre()))
+	event, _ := kever.Event(0)
+	if len(event.Vrcs) != 1 || len(kevery.Escrowed(cesr.Escrow_UnverifiedReceipt)) != 0 {
+		t.Fatalf("expected validator receipt to be verified")
+	}
+
+	// a receipt signed by the wrong keys is rejected
+	receipt.Vrcs[0].Sigers = keverTestSign(t, icp, signers)
+	if err := kevery.ProcessSignedEvent(receipt); err == nil {
+		t.Fatalf("expected invalid receipt to be rejected")
+	}
+}
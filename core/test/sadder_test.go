@@ -1,8 +1,13 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/jasoncolburne/cesrgo"
+	"github.com/jasoncolburne/cesrgo/common"
 	cesr "github.com/jasoncolburne/cesrgo/core"
 	"github.com/jasoncolburne/cesrgo/core/types"
 )
@@ -18,12 +23,297 @@ func TestSadderMarshalling(t *testing.T) {
 		t.Fatalf("failed to create sadder: %v", err)
 	}
 
-	json, err := sadder.GetKed().MarshalJSON()
+	serialized, err := sadder.GetKed().MarshalJSON()
 	if err != nil {
 		t.Fatalf("failed to marshal: %v", err)
 	}
 
-	if string(json) != "{\"v\":\"KERICAACAAJSONAABO.\",\"d\":\"EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU\"}" {
-		t.Fatalf("json mismatch: %s", string(json))
+	if string(serialized) != "{\"v\":\"KERICAACAAJSONAABO.\",\"d\":\"EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU\"}" {
+		t.Fatalf("json mismatch: %s", string(serialized))
+	}
+}
+
+func TestSadderSaidifiedRaw(t *testing.T) {
+	ked := types.NewMap()
+
+	ked.Set("v", "KERICAACAAJSONAAAA.")
+	ked.Set("d", "")
+
+	sadder, err := cesr.NewSadder(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	said, _ := sadder.GetKed().Get("d")
+
+	raw := sadder.GetRaw()
+	if !bytes.Contains(raw, []byte(said.(string))) || bytes.Contains(raw, []byte("#")) {
+		t.Fatalf("expected raw to carry the said: %s", raw)
+	}
+
+	if _, err := cesr.NewSadder(nil, &raw, nil, nil, true); err != nil {
+		t.Fatalf("failed to verify said of raw: %v", err)
+	}
+}
+
+func TestSadderJSONNumbers(t *testing.T) {
+	data := []byte(`{"n":9007199254740993,"m":{"f":1.5,"l":[2]}}`)
+
+	var ked types.Map
+	if err := json.Unmarshal(data, &ked); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	n, _ := ked.Get("n")
+	if number, ok := n.(json.Number); !ok || number != "9007199254740993" {
+		t.Fatalf("expected json.Number, got %T %v", n, n)
+	}
+
+	m, _ := ked.Get("m")
+	inner, ok := m.(types.Map)
+	if !ok {
+		t.Fatalf("expected nested map, got %T", m)
+	}
+
+	f, _ := inner.Get("f")
+	if number, ok := f.(json.Number); !ok || number != "1.5" {
+		t.Fatalf("expected json.Number, got %T %v", f, f)
+	}
+
+	l, _ := inner.Get("l")
+	if list, ok := l.([]any); !ok || len(list) != 1 || list[0] != json.Number("2") {
+		t.Fatalf("expected list of json.Number, got %T %v", l, l)
+	}
+
+	serialized, err := json.Marshal(ked)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if !bytes.Equal(serialized, data) {
+		t.Fatalf("json mismatch: %s", serialized)
+	}
+}
+
+func TestSadderCBOR(t *testing.T) {
+	nested := types.NewMap()
+	nested.Set("z", "last")
+	nested.Set("a", []any{"first", "second"})
+
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAACBORAAAA.")
+	ked.Set("d", "")
+	ked.Set("n", nested)
+
+	kind := cesrgo.Kind_CBOR
+	sadder, err := cesr.NewSadder(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	raw := sadder.GetRaw()
+	if raw[0] != 0xa3 {
+		t.Fatalf("expected a cbor map of 3 entries, got %x", raw[0])
+	}
+
+	_, _, smelledKind, size, _, err := common.Smell(raw)
+	if err != nil {
+		t.Fatalf("failed to smell: %v", err)
+	}
+
+	if smelledKind != cesrgo.Kind_CBOR || int(size) != len(raw) {
+		t.Fatalf("unexpected version string: kind = %s, size = %d, len = %d", smelledKind, size, len(raw))
+	}
+
+	loaded, err := cesr.NewSadder(nil, &raw, nil, nil, false)
+	if err != nil {
+		t.Fatalf("failed to load sadder: %v", err)
+	}
+
+	if loaded.GetKind() != cesrgo.Kind_CBOR {
+		t.Fatalf("kind mismatch: %s", loaded.GetKind())
+	}
+
+	loadedKed := loaded.GetKed()
+	if strings.Join(loadedKed.Keys(), "") != "vdn" {
+		t.Fatalf("field order not preserved: %v", loadedKed.Keys())
+	}
+
+	loadedNested, ok := loadedKed.Get("n")
+	if !ok {
+		t.Fatalf("n not found")
+	}
+
+	nestedMap, ok := loadedNested.(types.Map)
+	if !ok || strings.Join(nestedMap.Keys(), "") != "za" {
+		t.Fatalf("nested field order not preserved: %v", loadedNested)
+	}
+
+	d, _ := loadedKed.Get("d")
+	said, ok := d.(string)
+	if !ok {
+		t.Fatalf("d not a string")
+	}
+
+	saider, err := cesr.NewSaider(&loadedKed, nil, &kind)
+	if err != nil {
+		t.Fatalf("failed to create saider: %v", err)
+	}
+
+	qb64, err := saider.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if string(qb64) != said {
+		t.Fatalf("said mismatch: %s != %s", qb64, said)
+	}
+}
+
+func TestSadderMGPK(t *testing.T) {
+	nested := types.NewMap()
+	nested.Set("z", "last")
+	nested.Set("a", types.List{"first", int64(1)})
+
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAAMGPKAAAA.")
+	ked.Set("d", "")
+	ked.Set("n", nested)
+
+	kind := cesrgo.Kind_MGPK
+	sadder, err := cesr.NewSadder(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	raw := sadder.GetRaw()
+	_, _, smelledKind, size, _, err := common.Smell(raw)
+	if err != nil {
+		t.Fatalf("failed to smell: %v", err)
+	}
+
+	if smelledKind != cesrgo.Kind_MGPK || int(size) != len(raw) {
+		t.Fatalf("unexpected version string: kind = %s, size = %d, len = %d", smelledKind, size, len(raw))
+	}
+
+	v, _ := sadder.GetKed().Get("v")
+	d, _ := sadder.GetKed().Get("d")
+
+	// the encoding msgpack-python produces for the same dict
+	expected := []byte{0x83, 0xa1, 'v', 0xb3}
+	expected = append(expected, v.(string)...)
+	expected = append(expected, 0xa1, 'd', 0xd9, 0x2c)
+	expected = append(expected, d.(string)...)
+	expected = append(expected, 0xa1, 'n', 0x82, 0xa1, 'z', 0xa4)
+	expected = append(expected, "last"...)
+	expected = append(expected, 0xa1, 'a', 0x92, 0xa5)
+	expected = append(expected, "first"...)
+	expected = append(expected, 0x01)
+
+	if !bytes.Equal(raw, expected) {
+		t.Fatalf("raw mismatch: %x != %x", raw, expected)
+	}
+
+	loaded, err := cesr.NewSadder(nil, &raw, nil, nil, false)
+	if err != nil {
+		t.Fatalf("failed to load sadder: %v", err)
+	}
+
+	loadedKed := loaded.GetKed()
+	if loaded.GetKind() != cesrgo.Kind_MGPK || strings.Join(loadedKed.Keys(), "") != "vdn" {
+		t.Fatalf("unexpected loaded sadder: kind = %s, keys = %v", loaded.GetKind(), loadedKed.Keys())
+	}
+
+	saider, err := cesr.NewSaider(&loadedKed, nil, &kind)
+	if err != nil {
+		t.Fatalf("failed to create saider: %v", err)
+	}
+
+	qb64, err := saider.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	if string(qb64) != d {
+		t.Fatalf("said mismatch: %s != %s", qb64, d)
+	}
+}
+
+func TestSadderVersion1(t *testing.T) {
+	proto := cesrgo.Proto_KERI
+	kind := cesrgo.Kind_JSON
+
+	vs, err := common.Versify(&proto, &cesrgo.VERSION_1_0, &kind, 0x11c, nil)
+	if err != nil {
+		t.Fatalf("failed to versify: %v", err)
+	}
+
+	if vs != "KERI10JSON00011c_" {
+		t.Fatalf("version string mismatch: %s", vs)
+	}
+
+	ked := types.NewMap()
+	ked.Set("v", "KERI10JSON000000_")
+	ked.Set("d", "")
+
+	sadder, err := cesr.NewSadder(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	raw := sadder.GetRaw()
+	if !strings.HasPrefix(string(raw), "{\"v\":\"KERI10JSON00004c_\"") || len(raw) != 0x4c {
+		t.Fatalf("unexpected raw: %s", raw)
+	}
+
+	if sadder.GetVersion() != cesrgo.VERSION_1_0 {
+		t.Fatalf("version mismatch: %v", sadder.GetVersion())
+	}
+
+	loaded, err := cesr.NewSadder(nil, &raw, nil, nil, true)
+	if err != nil {
+		t.Fatalf("failed to load sadder: %v", err)
+	}
+
+	if loaded.GetVersion() != cesrgo.VERSION_1_0 || loaded.GetSize() != 0x4c {
+		t.Fatalf("unexpected loaded sadder: version = %v, size = %d", loaded.GetVersion(), loaded.GetSize())
+	}
+
+	ckind := cesrgo.Kind_CESR
+	if _, err := common.Versify(&proto, &cesrgo.VERSION_1_0, &ckind, 0, nil); err == nil {
+		t.Fatalf("expected error for v1 native version string")
+	}
+}
+
+func TestSizeifyVersion(t *testing.T) {
+	ked := types.NewMap()
+	ked.Set("v", "KERI10JSON000000_")
+	ked.Set("d", "")
+
+	// without a version, the message must declare the current version
+	if _, _, _, _, _, err := common.Sizeify(ked, nil, nil); err == nil {
+		t.Fatalf("expected v1 message to be rejected without an explicit version")
+	}
+
+	raw, _, _, sized, pvrsn, err := common.Sizeify(ked, nil, &cesrgo.VERSION_1_0)
+	if err != nil {
+		t.Fatalf("failed to sizeify: %v", err)
+	}
+
+	v, _ := sized.Get("v")
+	if v != "KERI10JSON000020_" || len(raw) != 0x20 || pvrsn != cesrgo.VERSION_1_0 {
+		t.Fatalf("unexpected sizeify: %v %d %v", v, len(raw), pvrsn)
+	}
+
+	current := types.NewMap()
+	current.Set("v", "KERICAACAAJSONAAAA.")
+	current.Set("d", "")
+
+	if _, _, _, _, pvrsn, err := common.Sizeify(current, nil, nil); err != nil || pvrsn != cesrgo.VERSION {
+		t.Fatalf("failed to sizeify current version: %v %v", pvrsn, err)
+	}
+
+	if _, _, _, _, _, err := common.Sizeify(current, nil, &cesrgo.VERSION_1_0); err == nil {
+		t.Fatalf("expected version mismatch to be rejected")
 	}
 }
@@ -31,6 +31,35 @@ func TestSaiderSaidification(t *testing.T) {
 	}
 }
 
+// TestSaiderSizedSaid checks that a Saider and a Sadder agree on the SAID of a message whose version
+// string is not yet sized
+func TestSaiderSizedSaid(t *testing.T) {
+	ked := types.NewMap()
+	ked.Set("v", "KERICAACAAJSONAAAA.")
+	ked.Set("d", "")
+	ked.Set("a", "")
+
+	saider, err := cesr.NewSaider(&ked, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create saider: %v", err)
+	}
+
+	sadder, err := cesr.NewSadder(nil, nil, &ked, nil, true)
+	if err != nil {
+		t.Fatalf("failed to create sadder: %v", err)
+	}
+
+	said, err := saider.Qb64()
+	if err != nil {
+		t.Fatalf("failed to get qb64: %v", err)
+	}
+
+	d, _ := sadder.GetKed().Get("d")
+	if string(said) != d {
+		t.Fatalf("said mismatch: %s != %s", said, d)
+	}
+}
+
 func TestSaiderRoundTrip(t *testing.T) {
 	raw := [32]byte{}
 	_, err := rand.Read(raw[:])
@@ -0,0 +1,183 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo"
+	cesr "github.com/jasoncolburne/cesrgo/core"
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+func TestSerderKERIInception(t *testing.T) {
+	for _, kind := range []types.Kind{cesrgo.Kind_JSON, cesrgo.Kind_CBOR, cesrgo.Kind_MGPK, cesrgo.Kind_CESR} {
+		ked := nativeTestInception(t)
+		ked.Set("i", "")
+
+		serder, err := cesr.NewSerderKERI(nil, nil, &ked, &kind, true)
+		if err != nil {
+			t.Fatalf("failed to create serder (%s): %v", kind, err)
+		}
+
+		if serder.Said() == "" || serder.Pre() != serder.Said() {
+			t.Fatalf("expected self-addressing prefix: %s != %s", serder.Pre(), serder.Said())
+		}
+
+		raw := serder.GetRaw()
+		loaded, err := cesr.NewSerderKERI(nil, &raw, nil, nil, true)
+		if err != nil {
+			t.Fatalf("failed to load serder (%s): %v", kind, err)
+		}
+
+		if !bytes.Equal(loaded.GetRaw(), raw) || loaded.GetKind() != kind {
+			t.Fatalf("round trip mismatch (%s)", kind)
+		}
+
+		if loaded.Ilk() != cesrgo.Ilk_ICP || !loaded.Estive() {
+			t.Fatalf("unexpected ilk: %s", loaded.Ilk())
+		}
+
+		sn, err := loaded.Sn()
+		if err != nil || sn.Sign() != 0 {
+			t.Fatalf("unexpected sn: %v %v", sn, err)
+		}
+
+		verfers, err := loaded.Verfers()
+		if err != nil || len(verfers) != 1 {
+			t.Fatalf("unexpected verfers: %v %v", verfers, err)
+		}
+
+		digers, err := loaded.NextDigers()
+		if err != nil || len(digers) != 2 || digers[0].GetCode() != codex.Blake3_256 {
+			t.Fatalf("unexpected digers: %v %v", digers, err)
+		}
+
+		tholder, err := loaded.Tholder()
+		if err != nil || tholder.Weighted() || tholder.Size() != 1 {
+			t.Fatalf("unexpected tholder: %v", err)
+		}
+
+		ntholder, err := loaded.NextTholder()
+		if err != nil || !ntholder.Weighted() || ntholder.Size() != 2 {
+			t.Fatalf("unexpected next tholder: %v", err)
+		}
+
+		traitors, err := loaded.Traitors()
+		if err != nil || len(traitors) != 1 {
+			t.Fatalf("unexpected traitors: %v %v", traitors, err)
+		}
+
+		trait, err := traitors[0].Trait()
+		if err != nil || trait != cesrgo.Trait_EstOnly {
+			t.Fatalf("unexpected trait: %s %v", trait, err)
+		}
+
+		backers, err := loaded.Backers()
+		if err != nil || len(backers) != 0 {
+			t.Fatalf("unexpected backers: %v %v", backers, err)
+		}
+
+		prefixer, err := loaded.Prefixer()
+		if err != nil {
+			t.Fatalf("failed to get prefixer: %v", err)
+		}
+
+		pre, err := prefixer.Qb64()
+		if err != nil || string(pre) != loaded.Said() {
+			t.Fatalf("unexpected prefixer: %s %v", pre, err)
+		}
+	}
+}
+
+func TestSerderKERIVerifiesSaid(t *testing.T) {
+	ked := nativeTestInception(t)
+
+	kind := cesrgo.Kind_JSON
+	serder, err := cesr.NewSerderKERI(nil, nil, &ked, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create serder: %v", err)
+	}
+
+	// a basic prefix is not replaced with the said
+	if serder.Pre() == serder.Said() {
+		t.Fatalf("unexpected self-addressing prefix")
+	}
+
+	raw := types.Raw(bytes.Replace(serder.GetRaw(), []byte(`"s":"0"`), []byte(`"s":"1"`), 1))
+	if _, err := cesr.NewSerderKERI(nil, &raw, nil, nil, true); err == nil {
+		t.Fatalf("expected said mismatch")
+	}
+
+	if _, err := cesr.NewSerderKERI(nil, &raw, nil, nil, false); err != nil {
+		t.Fatalf("unexpected error without saidify: %v", err)
+	}
+}
+
+func TestSerderKERIFields(t *testing.T) {
+	kind := cesrgo.Kind_JSON
+
+	ked := nativeTestInception(t)
+	ked.Delete("c")
+
+	if _, err := cesr.NewSerderKERI(nil, nil, &ked, &kind, true); err == nil {
+		t.Fatalf("expected missing field error")
+	}
+
+	ked = nativeTestInception(t)
+	ked.Delete("a")
+	ked.Set("a", []any{})
+	ked.Delete("bt")
+	ked.Set("bt", "0")
+
+	if _, err := cesr.NewSerderKERI(nil, nil, &ked, &kind, true); err == nil {
+		t.Fatalf("expected field order error")
+	}
+
+	prior := nativeTestInception(t)
+	serder, err := cesr.NewSerderKERI(nil, nil, &prior, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create serder: %v", err)
+	}
+
+	rot := types.NewMap()
+	rot.Set("v", "KERI10JSON000000_")
+	rot.Set("t", "rot")
+	rot.Set("d", "")
+	rot.Set("i", serder.Pre())
+	rot.Set("s", "1")
+	rot.Set("p", serder.Said())
+	rot.Set("kt", "1")
+	rot.Set("k", []any{serder.Pre()})
+	rot.Set("nt", "0")
+	rot.Set("n", []any{})
+	rot.Set("bt", "0")
+	rot.Set("br", []any{})
+	rot.Set("ba", []any{})
+	rot.Set("a", []any{})
+
+	rotation, err := cesr.NewSerderKERI(nil, nil, &rot, &kind, true)
+	if err != nil {
+		t.Fatalf("failed to create v1 rotation: %v", err)
+	}
+
+	if rotation.GetVersion() != cesrgo.VERSION_1_0 {
+		t.Fatalf("unexpected version: %v", rotation.GetVersion())
+	}
+
+	prior2, err := rotation.Prior()
+	if err != nil {
+		t.Fatalf("failed to get prior: %v", err)
+	}
+
+	p, err := prior2.Qb64()
+	if err != nil || string(p) != serder.Said() {
+		t.Fatalf("unexpected prior: %s %v", p, err)
+	}
+
+	// v2 rotations carry configuration traits
+	rot.Set("v", "KERICAACAAJSONAAAA.")
+	if _, err := cesr.NewSerderKERI(nil, nil, &rot, &kind, true); err == nil {
+		t.Fatalf("expected missing field error")
+	}
+}
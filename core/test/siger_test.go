@@ -44,6 +44,12 @@ func TestSigerCodesAndIndicies(t *testing.T) {
 			Only:       false,
 			Index:      63,
 		},
+		{
+			SignerCode: mdex.Ed448_Seed,
+			SigerCode:  idex.Ed448,
+			Only:       false,
+			Index:      12,
+		},
 		{
 			SignerCode: mdex.Ed25519_Seed,
 			SigerCode:  idex.Ed25519_Big,
@@ -65,6 +71,13 @@ func TestSigerCodesAndIndicies(t *testing.T) {
 			Index:      64,
 			Ondex:      &_81,
 		},
+		{
+			SignerCode: mdex.Ed448_Seed,
+			SigerCode:  idex.Ed448_Big,
+			Only:       false,
+			Index:      12,
+			Ondex:      &_81,
+		},
 		{
 			SignerCode: mdex.Ed25519_Seed,
 			SigerCode:  idex.Ed25519_Crt,
@@ -83,6 +96,12 @@ func TestSigerCodesAndIndicies(t *testing.T) {
 			Only:       true,
 			Index:      63,
 		},
+		{
+			SignerCode: mdex.Ed448_Seed,
+			SigerCode:  idex.Ed448_Crt,
+			Only:       true,
+			Index:      12,
+		},
 		{
 			SignerCode: mdex.Ed25519_Seed,
 			SigerCode:  idex.Ed25519_Big_Crt,
@@ -101,6 +120,12 @@ func TestSigerCodesAndIndicies(t *testing.T) {
 			Only:       true,
 			Index:      66,
 		},
+		{
+			SignerCode: mdex.Ed448_Seed,
+			SigerCode:  idex.Ed448_Big_Crt,
+			Only:       true,
+			Index:      67,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -19,6 +19,7 @@ func TestSignerCodesAndSizes(t *testing.T) {
 		{Code: mdex.Ed25519_Seed},
 		{Code: mdex.ECDSA_256k1_Seed},
 		{Code: mdex.ECDSA_256r1_Seed},
+		{Code: mdex.Ed448_Seed},
 	}
 
 	for _, testCase := range testCases {
@@ -57,6 +58,11 @@ func TestSignerDeterminism(t *testing.T) {
 			Raw:           types.Raw("\x90\v=\x84?\xdeZ`n\xfex\xd2\xd3ҽ\x15\x04\x03DM\xe2Ď\x02*s%}\xf4\x84\xb0\x02"),
 			NullCigarQb64: types.Qb64("0IDp2liCC2HmkE3TaemOVpL2snwPoCYON58LIDbMOTKYPgAQ8T1utfNR2S3cren9N6XK7W_n9_Ci0C4x6Am6kezS"),
 		},
+		{
+			Code:          mdex.Ed448_Seed,
+			Raw:           types.Raw("l\x82\xa5bˀ\x8d\x10\xd62\xbe\x89\xc8Q>\xbfl\x92\x9f4\xdd\xfa\x8c\x9fcɖ\x0e\xf6\xe3H\xa3R\x8c\x8a?\xcc/\x04N9\xa3\xfc[\x94I/\x8f\x03.uI\xa2\x00\x98\xf9"),
+			NullCigarQb64: types.Qb64("1AAEp4iQR2rTu8V1zNxm_7BmVIF3aUxnDvLF8feyYhpluMVYt2KnB-41mlUjzK2q3N8VLtx8y9sJetWAq2rI3f8gGhxeFQC4jZrCwIUXTFuOxD6ELLIWxWYfLVNaHDMVMSW_8siF0dLEdtmmNT-e3WxHyhgA"),
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -98,6 +104,9 @@ func TestSignerUnindexedSignatureCreation(t *testing.T) {
 		{
 			Code: mdex.ECDSA_256r1_Seed,
 		},
+		{
+			Code: mdex.Ed448_Seed,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -129,6 +138,9 @@ func TestSignerIndexedSignatureCreation(t *testing.T) {
 		{
 			Code: mdex.ECDSA_256r1_Seed,
 		},
+		{
+			Code: mdex.Ed448_Seed,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -3,6 +3,7 @@ package test
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -41,6 +42,14 @@ func TestVerferCodesAndSizes(t *testing.T) {
 			Code: codex.ECDSA_256r1N,
 			Size: 33,
 		},
+		{
+			Code: codex.Ed448,
+			Size: 57,
+		},
+		{
+			Code: codex.Ed448N,
+			Size: 57,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -83,6 +92,9 @@ func TestVerferVerification(t *testing.T) {
 		{
 			SignerCode: codex.ECDSA_256r1_Seed,
 		},
+		{
+			SignerCode: codex.Ed448_Seed,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -144,6 +156,56 @@ func TestVerferVerification(t *testing.T) {
 	}
 }
 
+// TestVerferEd448Vectors verifies the RFC 8032 section 7.4 Ed448 test vectors. Their 57 octet secret
+// keys have no 448 bit Ed448_Seed form, so only verification is covered.
+func TestVerferEd448Vectors(t *testing.T) {
+	testCases := []struct {
+		Label     string
+		PublicKey string
+		Message   string
+		Signature string
+	}{
+		{
+			Label:     "blank",
+			PublicKey: "5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180",
+			Message:   "",
+			Signature: "533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074fd823d41a591f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d2028d4b18a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a113a0f4dbb61149f05a7363268c71d95808ff2e652600",
+		},
+		{
+			Label:     "1 octet",
+			PublicKey: "43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+			Message:   "03",
+			Signature: "26b8f91727bd62897af15e41eb43c377efb9c610d48f2335cb0bd0087810f4352541b143c4b981b7e18f62de8ccdf633fc1bf037ab7cd779805e0dbcc0aae1cbcee1afb2e027df36bc04dcecbf154336c19f0af7e0a6472905e799f1953d2a0ff3348ab21aa4adafd1d234441cf807c03a00",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			pub, _ := hex.DecodeString(testCase.PublicKey)
+			msg, _ := hex.DecodeString(testCase.Message)
+			sig, _ := hex.DecodeString(testCase.Signature)
+
+			verfer, err := cesr.NewVerfer(options.WithCode(codex.Ed448), options.WithRaw(pub))
+			if err != nil {
+				t.Fatalf("failed to create verfer: %v", err)
+			}
+
+			verified, err := verfer.Verify(sig, msg)
+			if err != nil || !verified {
+				t.Fatalf("invalid signature: %v", err)
+			}
+
+			tampered := append([]byte{}, msg...)
+			tampered = append(tampered, 0)
+
+			verified, err = verfer.Verify(sig, tampered)
+			if err == nil || verified {
+				t.Fatalf("unexpected valid signature")
+			}
+		})
+	}
+}
+
 func TestVerferRoundTrip(t *testing.T) {
 	raw := [32]byte{}
 	_, err := rand.Read(raw[:])
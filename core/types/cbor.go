@@ -0,0 +1,205 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	cborMajorArray = 4
+	cborMajorMap   = 5
+
+	cborIndefinite = 31
+	cborBreak      = 0xff
+)
+
+// MarshalCBOR encodes the map with its keys in insertion order
+func (m Map) MarshalCBOR() ([]byte, error) {
+	om := m._map()
+
+	buf := bytes.Buffer{}
+	buf.Write(cborHead(cborMajorMap, uint64(om.Len())))
+
+	for pair := om.Oldest(); pair != nil; pair = pair.Next() {
+		key, err := cbor.Marshal(pair.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := cbor.Marshal(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.Write(value)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalCBOR decodes nested maps as Maps so that field order survives a round trip
+func (m *Map) UnmarshalCBOR(data []byte) error {
+	value, rest, err := decodeCBORValue(data)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) > 0 {
+		return fmt.Errorf("unexpected trailing cbor data: %d bytes", len(rest))
+	}
+
+	decoded, ok := value.(Map)
+	if !ok {
+		return fmt.Errorf("expected cbor map, got %T", value)
+	}
+
+	*m = decoded
+
+	return nil
+}
+
+func cborHead(major byte, n uint64) []byte {
+	major <<= 5
+
+	switch {
+	case n < 24:
+		return []byte{major | byte(n)}
+	case n <= 0xff:
+		return []byte{major | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major | 25}, uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major | 26}, uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major | 27}, n)
+	}
+}
+
+// cborLength reads the head of a map or array, returning its length (-1 when indefinite) and the remainder
+func cborLength(data []byte) (int, []byte, error) {
+	info := data[0] & 0x1f
+	data = data[1:]
+
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info == 24 && len(data) >= 1:
+		n, data = uint64(data[0]), data[1:]
+	case info == 25 && len(data) >= 2:
+		n, data = uint64(binary.BigEndian.Uint16(data)), data[2:]
+	case info == 26 && len(data) >= 4:
+		n, data = uint64(binary.BigEndian.Uint32(data)), data[4:]
+	case info == 27 && len(data) >= 8:
+		n, data = binary.BigEndian.Uint64(data), data[8:]
+	case info == cborIndefinite:
+		return -1, data, nil
+	default:
+		return 0, nil, fmt.Errorf("invalid cbor length")
+	}
+
+	if n > uint64(len(data)) {
+		return 0, nil, fmt.Errorf("cbor length exceeds data: %d", n)
+	}
+
+	return int(n), data, nil //nolint:gosec
+}
+
+func cborMore(data []byte, length int, i int) (bool, []byte, error) {
+	if length >= 0 {
+		return i < length, data, nil
+	}
+
+	if len(data) == 0 {
+		return false, nil, fmt.Errorf("unterminated cbor item")
+	}
+
+	if data[0] == cborBreak {
+		return false, data[1:], nil
+	}
+
+	return true, data, nil
+}
+
+func decodeCBORValue(data []byte) (any, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("unexpected end of cbor data")
+	}
+
+	switch data[0] >> 5 {
+	case cborMajorMap:
+		length, rest, err := cborLength(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		m := NewMap()
+		for i := 0; ; i++ {
+			more, remaining, err := cborMore(rest, length, i)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rest = remaining
+			if !more {
+				break
+			}
+
+			var key string
+			rest, err = cbor.UnmarshalFirst(rest, &key)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var value any
+			value, rest, err = decodeCBORValue(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			m.Set(key, value)
+		}
+
+		return m, rest, nil
+	case cborMajorArray:
+		length, rest, err := cborLength(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		l := []any{}
+		for i := 0; ; i++ {
+			more, remaining, err := cborMore(rest, length, i)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rest = remaining
+			if !more {
+				break
+			}
+
+			var value any
+			value, rest, err = decodeCBORValue(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			l = append(l, value)
+		}
+
+		return l, rest, nil
+	default:
+		var value any
+		rest, err := cbor.UnmarshalFirst(data, &value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return value, rest, nil
+	}
+}
@@ -46,6 +46,9 @@ type (
 		SetCount(count Count)
 		GetCount() Count
 
+		SetVersion(version Version)
+		GetVersion() Version
+
 		Qb2() (Qb2, error)
 		Qb64() (Qb64, error)
 		Qb64b() (Qb64b, error)
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// EncodeMsgpack encodes the map with its keys in insertion order
+func (m Map) EncodeMsgpack(enc *msgpack.Encoder) error {
+	om := m._map()
+
+	if err := enc.EncodeMapLen(om.Len()); err != nil {
+		return err
+	}
+
+	for pair := om.Oldest(); pair != nil; pair = pair.Next() {
+		if err := enc.EncodeString(pair.Key); err != nil {
+			return err
+		}
+
+		if err := enc.Encode(pair.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeMsgpack decodes nested maps as Maps so that field order survives a round trip
+func (m *Map) DecodeMsgpack(dec *msgpack.Decoder) error {
+	value, err := decodeMsgpackValue(dec)
+	if err != nil {
+		return err
+	}
+
+	decoded, ok := value.(Map)
+	if !ok {
+		return fmt.Errorf("expected msgpack map, got %T", value)
+	}
+
+	*m = decoded
+
+	return nil
+}
+
+func (l List) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeArrayLen(len(l)); err != nil {
+		return err
+	}
+
+	for _, value := range l {
+		if err := enc.Encode(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *List) DecodeMsgpack(dec *msgpack.Decoder) error {
+	value, err := decodeMsgpackValue(dec)
+	if err != nil {
+		return err
+	}
+
+	decoded, ok := value.([]any)
+	if !ok {
+		return fmt.Errorf("expected msgpack array, got %T", value)
+	}
+
+	*l = List(decoded)
+
+	return nil
+}
+
+func decodeMsgpackValue(dec *msgpack.Decoder) (any, error) {
+	c, err := dec.PeekCode()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case msgpcode.IsFixedMap(c) || c == msgpcode.Map16 || c == msgpcode.Map32:
+		n, err := dec.DecodeMapLen()
+		if err != nil {
+			return nil, err
+		}
+
+		m := NewMap()
+		for range n {
+			key, err := dec.DecodeString()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeMsgpackValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			m.Set(key, value)
+		}
+
+		return m, nil
+	case msgpcode.IsFixedArray(c) || c == msgpcode.Array16 || c == msgpcode.Array32:
+		n, err := dec.DecodeArrayLen()
+		if err != nil {
+			return nil, err
+		}
+
+		l := make([]any, 0, n)
+		for range n {
+			value, err := decodeMsgpackValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, value)
+		}
+
+		return l, nil
+	default:
+		return dec.DecodeInterface()
+	}
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 )
 
@@ -31,6 +35,7 @@ type (
 	Ilk   string
 	Trait string
 	Tier  string
+	Cold  string
 
 	DateTime string
 
@@ -71,12 +76,105 @@ func (m Map) Delete(key string) (any, bool) {
 	return om.Delete(key)
 }
 
+func (m Map) Len() int {
+	om := m._map()
+	return om.Len()
+}
+
+func (m Map) Keys() []string {
+	om := m._map()
+
+	keys := []string{}
+	for pair := om.Oldest(); pair != nil; pair = pair.Next() {
+		keys = append(keys, pair.Key)
+	}
+
+	return keys
+}
+
 func (m Map) MarshalJSON() ([]byte, error) {
 	om := m._map()
 	return om.MarshalJSON()
 }
 
-func (m Map) UnmarshalJSON(data []byte) error {
-	om := m._map()
-	return om.UnmarshalJSON(data)
+// UnmarshalJSON decodes nested objects as Maps so that field order survives a round trip. Numbers
+// decode as json.Number rather than float64, so integers above 2^53 are exact and re-serialize as they
+// were read, which a SAID over the serialization depends on. The receiver is a pointer so that a zero
+// Map can be filled.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return err
+	}
+
+	decoded, ok := value.(Map)
+	if !ok {
+		return fmt.Errorf("expected json object, got %T", value)
+	}
+
+	*m = decoded
+
+	return nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		m := NewMap()
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string key, got %T", keyToken)
+			}
+
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			m.Set(key, value)
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return m, nil
+	case '[':
+		l := []any{}
+		for decoder.More() {
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, value)
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return l, nil
+	default:
+		return nil, fmt.Errorf("unexpected delimiter: %s", delim)
+	}
 }
@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"fmt"
+
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"github.com/jasoncolburne/cesrgo/crypto/x25519"
+)
+
+// ConvertPublicKey converts a public signing key to the public encryption key of the same key pair
+func ConvertPublicKey(code types.Code, vk types.Raw) (types.Code, types.Raw, error) {
+	switch code {
+	case codex.Ed25519N, codex.Ed25519:
+		raw, err := x25519.ConvertPublicKey(vk)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return codex.X25519, raw, nil
+	default:
+		return "", nil, fmt.Errorf("unimplemented conversion of public key code: %s", code)
+	}
+}
+
+// ConvertSeed converts a signing seed to the private decryption key of the same key pair
+func ConvertSeed(code types.Code, seed types.Raw) (types.Code, types.Raw, error) {
+	switch code {
+	case codex.Ed25519_Seed:
+		raw, err := x25519.ConvertSeed(seed)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return codex.X25519_Private, raw, nil
+	default:
+		return "", nil, fmt.Errorf("unimplemented conversion of seed code: %s", code)
+	}
+}
+
+// DeriveEncryptionKey derives the public encryption key of a private decryption key
+func DeriveEncryptionKey(code types.Code, raw types.Raw) (types.Code, types.Raw, error) {
+	switch code {
+	case codex.X25519_Private:
+		pub, err := x25519.DerivePublicKey(raw)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return codex.X25519, pub, nil
+	default:
+		return "", nil, fmt.Errorf("unimplemented private key code: %s", code)
+	}
+}
@@ -0,0 +1,48 @@
+package ed448
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// SEED_BYTES is the size of the Ed448_Seed code, which the CESR codex fixes at 448 bits (76 qb64
+// characters). An RFC 8032 section 5.2.5 private key is 57 octets, so this library derives the key from
+// the seed followed by a zero octet. The padding is a convention of this implementation: RFC 8032 keys
+// whose final octet is not zero have no Ed448_Seed encoding, and other implementations must pad the same
+// way to derive the same keys.
+const SEED_BYTES = 56
+
+func GenerateSeed() (types.Raw, error) {
+	seed := make(types.Raw, SEED_BYTES)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+
+	return seed, nil
+}
+
+func DerivePublicKey(seed types.Raw) (types.Raw, error) {
+	priv, err := privateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, ok := priv.Public().(ed448.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert public key to bytes")
+	}
+
+	return types.Raw(pub), nil
+}
+
+// privateKey extends a 56 octet seed with a zero octet to form the 57 octet RFC 8032 private key
+func privateKey(seed types.Raw) (ed448.PrivateKey, error) {
+	if len(seed) != SEED_BYTES {
+		return nil, fmt.Errorf("invalid seed length")
+	}
+
+	return ed448.NewKeyFromSeed(append(seed[:SEED_BYTES:SEED_BYTES], 0)), nil
+}
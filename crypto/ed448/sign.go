@@ -0,0 +1,16 @@
+package ed448
+
+import (
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/jasoncolburne/cesrgo/core/types"
+)
+
+// Sign produces a pure Ed448 signature, with an empty context
+func Sign(sk types.Raw, ser []byte) (types.Raw, error) {
+	priv, err := privateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Raw(ed448.Sign(priv, ser, "")), nil
+}
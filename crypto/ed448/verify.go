@@ -0,0 +1,26 @@
+package ed448
+
+import (
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+const VK_SIZE = ed448.PublicKeySize
+const SIG_SIZE = ed448.SignatureSize
+
+func Verify(sig, vk, ser []byte) error {
+	if len(sig) != SIG_SIZE {
+		return fmt.Errorf("invalid signature length")
+	}
+
+	if len(vk) != VK_SIZE {
+		return fmt.Errorf("invalid public key length")
+	}
+
+	if !ed448.Verify(vk, ser, sig, "") {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package crypto
+
+import (
+	"fmt"
+
+	codex "github.com/jasoncolburne/cesrgo/core/matter"
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"github.com/jasoncolburne/cesrgo/crypto/x25519"
+)
+
+// Encrypt encrypts plain to the public encryption key pub
+func Encrypt(code types.Code, pub types.Raw, plain []byte) (types.Raw, error) {
+	switch code {
+	case codex.X25519:
+		return x25519.Seal(pub, plain)
+	default:
+		return nil, fmt.Errorf("unimplemented encryption key code: %s", code)
+	}
+}
+
+// Decrypt decrypts cipher with the private decryption key priv
+func Decrypt(code types.Code, priv types.Raw, cipher []byte) ([]byte, error) {
+	switch code {
+	case codex.X25519_Private:
+		return x25519.Open(priv, cipher)
+	default:
+		return nil, fmt.Errorf("unimplemented decryption key code: %s", code)
+	}
+}
+
+// EncryptHPKE encrypts plain to the public encryption key pub with HPKE, in the Auth mode when the private
+// key of the sender is given and the Base mode otherwise
+func EncryptHPKE(code types.Code, pub types.Raw, sender types.Raw, plain []byte) (types.Raw, error) {
+	switch code {
+	case codex.X25519:
+		return x25519.SealHPKE(pub, sender, nil, nil, plain)
+	default:
+		return nil, fmt.Errorf("unimplemented hpke encryption key code: %s", code)
+	}
+}
+
+// DecryptHPKE decrypts cipher with the private decryption key priv, in the Auth mode when the public key of
+// the sender is given and the Base mode otherwise
+func DecryptHPKE(code types.Code, priv types.Raw, sender types.Raw, cipher []byte) ([]byte, error) {
+	switch code {
+	case codex.X25519_Private:
+		return x25519.OpenHPKE(priv, sender, nil, nil, cipher)
+	default:
+		return nil, fmt.Errorf("unimplemented hpke decryption key code: %s", code)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	codex "github.com/jasoncolburne/cesrgo/core/matter"
 	"github.com/jasoncolburne/cesrgo/core/types"
 	"github.com/jasoncolburne/cesrgo/crypto/ed25519"
+	"github.com/jasoncolburne/cesrgo/crypto/ed448"
 	"github.com/jasoncolburne/cesrgo/crypto/secp256k1"
 	"github.com/jasoncolburne/cesrgo/crypto/secp256r1"
+	"github.com/jasoncolburne/cesrgo/crypto/x25519"
 )
 
 func GenerateSeed(code types.Code) (types.Raw, error) {
@@ -23,6 +25,10 @@ func GenerateSeed(code types.Code) (types.Raw, error) {
 		seed, err = secp256r1.GenerateSeed()
 	case codex.ECDSA_256k1_Seed:
 		seed, err = secp256k1.GenerateSeed()
+	case codex.Ed448_Seed:
+		seed, err = ed448.GenerateSeed()
+	case codex.X25519_Private:
+		seed, err = x25519.GenerateSeed()
 	default:
 		return nil, fmt.Errorf("unimplemented seed code: %s", code)
 	}
@@ -68,6 +74,17 @@ func DeriveCodeAndPublicKey(code types.Code, raw types.Raw, transferable bool) (
 		if verferRaw, err = secp256k1.DerivePublicKey(raw); err != nil {
 			return "", nil, err
 		}
+	case codex.Ed448_Seed:
+		if transferable {
+			verferCode = codex.Ed448
+		} else {
+			verferCode = codex.Ed448N
+		}
+
+		var err error
+		if verferRaw, err = ed448.DerivePublicKey(raw); err != nil {
+			return "", nil, err
+		}
 	default:
 		return "", nil, fmt.Errorf("unimplemented seed code")
 	}
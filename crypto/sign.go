@@ -6,6 +6,7 @@ import (
 	codex "github.com/jasoncolburne/cesrgo/core/matter"
 	"github.com/jasoncolburne/cesrgo/core/types"
 	"github.com/jasoncolburne/cesrgo/crypto/ed25519"
+	"github.com/jasoncolburne/cesrgo/crypto/ed448"
 	"github.com/jasoncolburne/cesrgo/crypto/secp256k1"
 	"github.com/jasoncolburne/cesrgo/crypto/secp256r1"
 )
@@ -18,6 +19,8 @@ func Sign(code types.Code, sk types.Raw, ser []byte) (types.Raw, error) {
 		return secp256k1.Sign(sk, ser)
 	case codex.ECDSA_256r1_Seed:
 		return secp256r1.Sign(sk, ser)
+	case codex.Ed448_Seed:
+		return ed448.Sign(sk, ser)
 	default:
 		return nil, fmt.Errorf("unimplemented seed code: %s", code)
 	}
@@ -6,6 +6,7 @@ import (
 	codex "github.com/jasoncolburne/cesrgo/core/matter"
 	"github.com/jasoncolburne/cesrgo/core/types"
 	"github.com/jasoncolburne/cesrgo/crypto/ed25519"
+	"github.com/jasoncolburne/cesrgo/crypto/ed448"
 	"github.com/jasoncolburne/cesrgo/crypto/secp256k1"
 	"github.com/jasoncolburne/cesrgo/crypto/secp256r1"
 )
@@ -22,6 +23,8 @@ func VerifySignature(
 		return secp256k1.Verify(sig, []byte(vk), ser)
 	case codex.ECDSA_256r1N, codex.ECDSA_256r1:
 		return secp256r1.Verify(sig, []byte(vk), ser)
+	case codex.Ed448N, codex.Ed448:
+		return ed448.Verify(sig, []byte(vk), ser)
 	default:
 		return fmt.Errorf("unimplemented verification key code: %s", code)
 	}
@@ -0,0 +1,90 @@
+package x25519
+
+import (
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/cloudflare/circl/math/fp25519"
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// edwardsD is the constant d of the twisted Edwards curve of Ed25519, little endian
+var edwardsD = fp25519.Elt{
+	0xa3, 0x78, 0x59, 0x13, 0xca, 0x4d, 0xeb, 0x75,
+	0xab, 0xd8, 0x41, 0x41, 0x4d, 0x0a, 0x70, 0x00,
+	0x98, 0xe8, 0x79, 0x77, 0x79, 0x40, 0xc7, 0x8c,
+	0x73, 0xfe, 0x6f, 0x2b, 0xee, 0x6c, 0x03, 0x52,
+}
+
+// ConvertPublicKey maps an Ed25519 public key to the X25519 public key of the birationally equivalent
+// Montgomery curve, u = (1 + y) / (1 - y), as libsodium's crypto_sign_ed25519_pk_to_curve25519 does
+func ConvertPublicKey(vk types.Raw) (types.Raw, error) {
+	if len(vk) != fp25519.Size {
+		return nil, fmt.Errorf("invalid public key length")
+	}
+
+	y := fp25519.Elt{}
+	copy(y[:], vk)
+	y[fp25519.Size-1] &= 0x7f
+
+	reduced := y
+	fp25519.Modp(&reduced)
+	if reduced != y {
+		return nil, fmt.Errorf("invalid public key: non-canonical encoding")
+	}
+
+	one, yy, num, den, x := fp25519.Elt{}, fp25519.Elt{}, fp25519.Elt{}, fp25519.Elt{}, fp25519.Elt{}
+	fp25519.SetOne(&one)
+
+	// the point is on the curve when x^2 = (y^2 - 1) / (d y^2 + 1) has a solution
+	fp25519.Sqr(&yy, &y)
+	fp25519.Sub(&num, &yy, &one)
+	fp25519.Mul(&den, &edwardsD, &yy)
+	fp25519.Add(&den, &den, &one)
+
+	if !fp25519.IsZero(&num) && !fp25519.InvSqrt(&x, &num, &den) {
+		return nil, fmt.Errorf("invalid public key: not on curve")
+	}
+
+	fp25519.Add(&num, &one, &y)
+	fp25519.Sub(&den, &one, &y)
+
+	if fp25519.IsZero(&den) {
+		return nil, fmt.Errorf("invalid public key: small order")
+	}
+
+	u := fp25519.Elt{}
+	fp25519.Inv(&den, &den)
+	fp25519.Mul(&u, &num, &den)
+
+	pub := make(types.Raw, KEY_BYTES)
+	if err := fp25519.ToBytes(pub, &u); err != nil {
+		return nil, err
+	}
+
+	// clamped scalars are multiples of the cofactor, so a point of small order yields zero
+	if _, err := curve25519.X25519(curve25519.Basepoint, pub); err != nil {
+		return nil, fmt.Errorf("invalid public key: small order")
+	}
+
+	return pub, nil
+}
+
+// ConvertSeed maps an Ed25519 seed to the X25519 private key with the same scalar, the clamped low
+// half of its SHA-512 digest, as libsodium's crypto_sign_ed25519_sk_to_curve25519 does
+func ConvertSeed(seed types.Raw) (types.Raw, error) {
+	if len(seed) != SEED_BYTES {
+		return nil, fmt.Errorf("invalid seed length")
+	}
+
+	digest := sha512.Sum512(seed)
+
+	priv := make(types.Raw, SEED_BYTES)
+	copy(priv, digest[:SEED_BYTES])
+	priv[0] &= 248
+	priv[31] &= 127
+	priv[31] |= 64
+
+	return priv, nil
+}
@@ -0,0 +1,34 @@
+package x25519
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+const SEED_BYTES = curve25519.ScalarSize
+const KEY_BYTES = curve25519.PointSize
+
+func GenerateSeed() (types.Raw, error) {
+	seed := make(types.Raw, SEED_BYTES)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+
+	return seed, nil
+}
+
+func DerivePublicKey(seed types.Raw) (types.Raw, error) {
+	if len(seed) != SEED_BYTES {
+		return nil, fmt.Errorf("invalid seed length")
+	}
+
+	pub, err := curve25519.X25519(seed, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Raw(pub), nil
+}
@@ -0,0 +1,105 @@
+package x25519
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// suite is RFC 9180 DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and ChaCha20-Poly1305
+var suite = hpke.NewSuite(hpke.KEM_X25519_HKDF_SHA256, hpke.KDF_HKDF_SHA256, hpke.AEAD_ChaCha20Poly1305)
+
+// HPKE_OVERHEAD is the size of the encapsulated key and authentication tag of an HPKE ciphertext
+const HPKE_OVERHEAD = KEY_BYTES + chacha20poly1305.Overhead
+
+// SealHPKE encrypts plain to pub in a single shot HPKE context bound to info, authenticating aad, and
+// returns the encapsulated key followed by the ciphertext. The Base mode is used unless sender, the
+// private key of the sender, is given for the Auth mode.
+func SealHPKE(pub types.Raw, sender types.Raw, info, aad, plain []byte) (types.Raw, error) {
+	scheme := hpke.KEM_X25519_HKDF_SHA256.Scheme()
+
+	pkR, err := scheme.UnmarshalBinaryPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := suite.NewSender(pkR, info)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		enc    []byte
+		sealer hpke.Sealer
+	)
+
+	if sender == nil {
+		enc, sealer, err = s.Setup(rand.Reader)
+	} else {
+		skS, uerr := scheme.UnmarshalBinaryPrivateKey(sender)
+		if uerr != nil {
+			return nil, uerr
+		}
+
+		enc, sealer, err = s.SetupAuth(rand.Reader, skS)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ct, err := sealer.Seal(plain, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(enc, ct...), nil
+}
+
+// OpenHPKE decrypts the output of SealHPKE with the private key priv, given the same info and aad. In the
+// Auth mode, sender is the public key of the sender.
+func OpenHPKE(priv types.Raw, sender types.Raw, info, aad, sealed []byte) ([]byte, error) {
+	if len(sealed) < HPKE_OVERHEAD {
+		return nil, fmt.Errorf("invalid hpke ciphertext length")
+	}
+
+	scheme := hpke.KEM_X25519_HKDF_SHA256.Scheme()
+
+	skR, err := scheme.UnmarshalBinaryPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := suite.NewReceiver(skR, info)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, ct := sealed[:KEY_BYTES], sealed[KEY_BYTES:]
+
+	var opener hpke.Opener
+	if sender == nil {
+		opener, err = r.Setup(enc)
+	} else {
+		pkS, uerr := scheme.UnmarshalBinaryPublicKey(sender)
+		if uerr != nil {
+			return nil, uerr
+		}
+
+		opener, err = r.SetupAuth(enc, pkS)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := opener.Open(ct, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open hpke ciphertext: %w", err)
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,41 @@
+package x25519
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/jasoncolburne/cesrgo/core/types"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// SEAL_OVERHEAD is the size of the ephemeral public key and authentication tag of a sealed box
+const SEAL_OVERHEAD = box.AnonymousOverhead
+
+// Seal encrypts plain to pub in a sealed box, compatible with libsodium's crypto_box_seal
+func Seal(pub types.Raw, plain []byte) (types.Raw, error) {
+	if len(pub) != KEY_BYTES {
+		return nil, fmt.Errorf("invalid public key length")
+	}
+
+	sealed, err := box.SealAnonymous(nil, plain, (*[KEY_BYTES]byte)(pub), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Raw(sealed), nil
+}
+
+// Open decrypts a sealed box with the private key priv
+func Open(priv types.Raw, sealed []byte) ([]byte, error) {
+	pub, err := DerivePublicKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, ok := box.OpenAnonymous(nil, sealed, (*[KEY_BYTES]byte)(pub), (*[SEED_BYTES]byte)(priv))
+	if !ok {
+		return nil, fmt.Errorf("failed to open sealed box")
+	}
+
+	return plain, nil
+}
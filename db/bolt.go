@@ -0,0 +1,225 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStore is a Store in a single bbolt file. A set is held in a nested bucket at its key, ordered
+// by insertion sequence.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens the store at path, creating it if necessary
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) View(fn func(Tx) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (s *BoltStore) Update(fn func(Tx) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+// bucket returns the named bucket for writing, creating it if necessary
+func (tx *boltTx) bucket(bucket Bucket) (*bolt.Bucket, error) {
+	if !tx.tx.Writable() {
+		return nil, fmt.Errorf("read only transaction")
+	}
+
+	return tx.tx.CreateBucketIfNotExists([]byte(bucket))
+}
+
+func (tx *boltTx) Get(bucket Bucket, key []byte) ([]byte, error) {
+	b := tx.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil, nil
+	}
+
+	val := b.Get(key)
+	if val == nil {
+		return nil, nil
+	}
+
+	return append([]byte{}, val...), nil
+}
+
+func (tx *boltTx) Put(bucket Bucket, key, val []byte) error {
+	b, err := tx.bucket(bucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Put(key, val)
+}
+
+func (tx *boltTx) Delete(bucket Bucket, key []byte) error {
+	b, err := tx.bucket(bucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(key)
+}
+
+func (tx *boltTx) Iterate(bucket Bucket, prefix []byte, fn func(key, val []byte) bool) error {
+	b := tx.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+
+	c := b.Cursor()
+	for key, val := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, val = c.Next() {
+		// nested buckets hold sets
+		if val == nil {
+			continue
+		}
+
+		if !fn(bytes.Clone(key), bytes.Clone(val)) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (tx *boltTx) Vals(bucket Bucket, key []byte) ([][]byte, error) {
+	vals := [][]byte{}
+
+	b := tx.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return vals, nil
+	}
+
+	set := b.Bucket(key)
+	if set == nil {
+		return vals, nil
+	}
+
+	err := set.ForEach(func(_, val []byte) error {
+		vals = append(vals, bytes.Clone(val))
+		return nil
+	})
+
+	return vals, err
+}
+
+func (tx *boltTx) AddVal(bucket Bucket, key, val []byte) (bool, error) {
+	b, err := tx.bucket(bucket)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := b.CreateBucketIfNotExists(key)
+	if err != nil {
+		return false, err
+	}
+
+	c := set.Cursor()
+	for k, held := c.First(); k != nil; k, held = c.Next() {
+		if bytes.Equal(held, val) {
+			return false, nil
+		}
+	}
+
+	seq, err := set.NextSequence()
+	if err != nil {
+		return false, err
+	}
+
+	return true, set.Put(binary.BigEndian.AppendUint64(nil, seq), val)
+}
+
+func (tx *boltTx) DeleteVal(bucket Bucket, key, val []byte) error {
+	b, err := tx.bucket(bucket)
+	if err != nil {
+		return err
+	}
+
+	set := b.Bucket(key)
+	if set == nil {
+		return nil
+	}
+
+	c := set.Cursor()
+	for k, held := c.First(); k != nil; k, held = c.Next() {
+		if bytes.Equal(held, val) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	if k, _ := set.Cursor().First(); k == nil {
+		return b.DeleteBucket(key)
+	}
+
+	return nil
+}
+
+func (tx *boltTx) DeleteVals(bucket Bucket, key []byte) error {
+	b, err := tx.bucket(bucket)
+	if err != nil {
+		return err
+	}
+
+	if b.Bucket(key) == nil {
+		return nil
+	}
+
+	return b.DeleteBucket(key)
+}
+
+func (tx *boltTx) IterateVals(bucket Bucket, prefix []byte, fn func(key, val []byte) bool) error {
+	b := tx.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+
+	c := b.Cursor()
+	for key, val := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, val = c.Next() {
+		if val != nil {
+			continue
+		}
+
+		set := b.Bucket(key)
+		if set == nil {
+			continue
+		}
+
+		sc := set.Cursor()
+		for k, held := sc.First(); k != nil; k, held = sc.Next() {
+			if !fn(bytes.Clone(key), bytes.Clone(held)) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is a Store held in memory, for tests and ephemeral nodes. Transactions are serialized.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	closed bool
+	data   memoryData
+}
+
+// memoryData holds the entries of each bucket by key. Committed entries are never modified in place.
+type memoryData map[Bucket]map[string]*memoryEntry
+
+// memoryEntry is a value or, like a nested bucket in a BoltStore, a set of values. A key holds one or
+// the other.
+type memoryEntry struct {
+	val []byte
+	set [][]byte
+}
+
+func (e *memoryEntry) isSet() bool {
+	return e.set != nil
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: memoryData{}}
+}
+
+func (s *MemoryStore) View(fn func(Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("store closed")
+	}
+
+	return fn(&memoryTx{base: s.data})
+}
+
+// Update runs fn against an overlay of the store, which is applied to it when fn succeeds
+func (s *MemoryStore) Update(fn func(Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("store closed")
+	}
+
+	tx := &memoryTx{base: s.data, writes: memoryData{}, writable: true}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	for bucket, writes := range tx.writes {
+		if s.data[bucket] == nil {
+			s.data[bucket] = map[string]*memoryEntry{}
+		}
+
+		for key, entry := range writes {
+			if entry == nil {
+				delete(s.data[bucket], key)
+			} else {
+				s.data[bucket][key] = entry
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	return nil
+}
+
+// memoryTx reads the committed store through the entries written by the transaction, where a nil entry
+// marks a deletion
+type memoryTx struct {
+	base     memoryData
+	writes   memoryData
+	writable bool
+}
+
+func (tx *memoryTx) write() error {
+	if !tx.writable {
+		return fmt.Errorf("read only transaction")
+	}
+
+	return nil
+}
+
+// entry returns the entry at key as the transaction sees it, or nil if there is none
+func (tx *memoryTx) entry(bucket Bucket, key string) *memoryEntry {
+	if entry, ok := tx.writes[bucket][key]; ok {
+		return entry
+	}
+
+	return tx.base[bucket][key]
+}
+
+func (tx *memoryTx) set(bucket Bucket, key string, entry *memoryEntry) {
+	if tx.writes[bucket] == nil {
+		tx.writes[bucket] = map[string]*memoryEntry{}
+	}
+
+	tx.writes[bucket][key] = entry
+}
+
+// keys lists the keys beginning with prefix that hold sets, or values, in order
+func (tx *memoryTx) keys(bucket Bucket, prefix []byte, sets bool) []string {
+	keys := []string{}
+	for _, key := range sortedKeys(prefix, tx.base[bucket], tx.writes[bucket]) {
+		entry := tx.entry(bucket, key)
+		if entry != nil && entry.isSet() == sets {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func (tx *memoryTx) Get(bucket Bucket, key []byte) ([]byte, error) {
+	entry := tx.entry(bucket, string(key))
+	if entry == nil || entry.isSet() {
+		return nil, nil
+	}
+
+	return slices.Clone(entry.val), nil
+}
+
+func (tx *memoryTx) Put(bucket Bucket, key, val []byte) error {
+	if err := tx.write(); err != nil {
+		return err
+	}
+
+	if len(key) == 0 {
+		return fmt.Errorf("empty key")
+	}
+
+	if entry := tx.entry(bucket, string(key)); entry != nil && entry.isSet() {
+		return fmt.Errorf("incompatible value: key holds a set")
+	}
+
+	tx.set(bucket, string(key), &memoryEntry{val: append([]byte{}, val...)})
+
+	return nil
+}
+
+func (tx *memoryTx) Delete(bucket Bucket, key []byte) error {
+	if err := tx.write(); err != nil {
+		return err
+	}
+
+	entry := tx.entry(bucket, string(key))
+	if entry == nil {
+		return nil
+	}
+
+	if entry.isSet() {
+		return fmt.Errorf("incompatible value: key holds a set")
+	}
+
+	tx.set(bucket, string(key), nil)
+
+	return nil
+}
+
+func (tx *memoryTx) Iterate(bucket Bucket, prefix []byte, fn func(key, val []byte) bool) error {
+	for _, key := range tx.keys(bucket, prefix, false) {
+		if !fn([]byte(key), slices.Clone(tx.entry(bucket, key).val)) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (tx *memoryTx) Vals(bucket Bucket, key []byte) ([][]byte, error) {
+	var set [][]byte
+	if entry := tx.entry(bucket, string(key)); entry != nil {
+		set = entry.set
+	}
+
+	vals := make([][]byte, len(set))
+	for i, val := range set {
+		vals[i] = slices.Clone(val)
+	}
+
+	return vals, nil
+}
+
+func (tx *memoryTx) AddVal(bucket Bucket, key, val []byte) (bool, error) {
+	if err := tx.write(); err != nil {
+		return false, err
+	}
+
+	if len(key) == 0 {
+		return false, fmt.Errorf("empty key")
+	}
+
+	var set [][]byte
+	if entry := tx.entry(bucket, string(key)); entry != nil {
+		if !entry.isSet() {
+			return false, fmt.Errorf("incompatible value: key holds a value")
+		}
+
+		set = entry.set
+	}
+
+	if slices.ContainsFunc(set, func(held []byte) bool { return bytes.Equal(held, val) }) {
+		return false, nil
+	}
+
+	// copy the set, since it may be shared with the committed store
+	tx.set(bucket, string(key), &memoryEntry{set: append(slices.Clip(set), append([]byte{}, val...))})
+
+	return true, nil
+}
+
+func (tx *memoryTx) DeleteVal(bucket Bucket, key, val []byte) error {
+	if err := tx.write(); err != nil {
+		return err
+	}
+
+	entry := tx.entry(bucket, string(key))
+	if entry == nil || !entry.isSet() {
+		return nil
+	}
+
+	set := slices.DeleteFunc(slices.Clone(entry.set), func(held []byte) bool {
+		return bytes.Equal(held, val)
+	})
+
+	if len(set) == 0 {
+		tx.set(bucket, string(key), nil)
+	} else {
+		tx.set(bucket, string(key), &memoryEntry{set: set})
+	}
+
+	return nil
+}
+
+func (tx *memoryTx) DeleteVals(bucket Bucket, key []byte) error {
+	if err := tx.write(); err != nil {
+		return err
+	}
+
+	if entry := tx.entry(bucket, string(key)); entry != nil && entry.isSet() {
+		tx.set(bucket, string(key), nil)
+	}
+
+	return nil
+}
+
+func (tx *memoryTx) IterateVals(bucket Bucket, prefix []byte, fn func(key, val []byte) bool) error {
+	for _, key := range tx.keys(bucket, prefix, true) {
+		for _, val := range tx.entry(bucket, key).set {
+			if !fn([]byte(key), slices.Clone(val)) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys lists the distinct keys of ms beginning with prefix, in order
+func sortedKeys[V any](prefix []byte, ms ...map[string]V) []string {
+	keys := []string{}
+	for _, m := range ms {
+		for key := range m {
+			if strings.HasPrefix(key, string(prefix)) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Bucket names a keyspace of a Store. A bucket holds either values, one per key, or sets of values
+// per key, never both.
+type Bucket string
+
+const (
+	// serialized events by prefix and digest
+	Bucket_Events = Bucket("evts")
+	// digests of the events of each key event log, by prefix and sequence number
+	Bucket_KELs = Bucket("kels")
+	// digests of events in first seen order, by prefix and first seen ordinal
+	Bucket_FirstSeen = Bucket("fels")
+	// first seen times, by prefix and digest
+	Bucket_Dates = Bucket("dtss")
+	// controller signature sets, by prefix and digest
+	Bucket_Sigs = Bucket("sigs")
+	// witness signature sets, by prefix and digest
+	Bucket_Wigs = Bucket("wigs")
+	// non-transferable receipt couple sets, by prefix and digest
+	Bucket_Receipts = Bucket("rcts")
+	// transferable receipt group sets, by prefix and digest
+	Bucket_Vrcs = Bucket("vrcs")
+	// delegation source seal couples, by prefix and digest
+	Bucket_Sources = Bucket("aess")
+	// latest key states, by prefix
+	Bucket_States = Bucket("stts")
+	// duplicitous event digest sets, by prefix and sequence number
+	Bucket_Duplicities = Bucket("dels")
+	// private keys, by public key
+	Bucket_Keys = Bucket("pris")
+
+	// escrowed event digest sets, by prefix and sequence number
+	Bucket_OutOfOrder         = Bucket("ooes")
+	Bucket_PartiallySigned    = Bucket("pses")
+	Bucket_PartiallyWitnessed = Bucket("pwes")
+	Bucket_MissingDelegator   = Bucket("ldes")
+	Bucket_PartiallyDelegated = Bucket("pdes")
+	Bucket_UnverifiedReceipt  = Bucket("ures")
+)
+
+// Store is an ordered key value store. Every read and write happens in a transaction.
+type Store interface {
+	// View runs fn in a read only transaction
+	View(fn func(Tx) error) error
+	// Update runs fn in a read write transaction, which is committed if fn returns nil and rolled
+	// back otherwise
+	Update(fn func(Tx) error) error
+	Close() error
+}
+
+// Tx reads and writes the buckets of a Store. Keys are ordered bytewise. Returned slices are copies
+// and remain valid after the transaction.
+type Tx interface {
+	// Get returns the value at key, or nil if there is none
+	Get(bucket Bucket, key []byte) ([]byte, error)
+	Put(bucket Bucket, key, val []byte) error
+	Delete(bucket Bucket, key []byte) error
+	// Iterate calls fn for each key beginning with prefix, in order, until fn returns false
+	Iterate(bucket Bucket, prefix []byte, fn func(key, val []byte) bool) error
+
+	// Vals returns the set at key in insertion order
+	Vals(bucket Bucket, key []byte) ([][]byte, error)
+	// AddVal adds val to the set at key, reporting whether it was not already present
+	AddVal(bucket Bucket, key, val []byte) (bool, error)
+	DeleteVal(bucket Bucket, key, val []byte) error
+	DeleteVals(bucket Bucket, key []byte) error
+	// IterateVals calls fn for each value of the sets at keys beginning with prefix, in key then
+	// insertion order, until fn returns false
+	IterateVals(bucket Bucket, prefix []byte, fn func(key, val []byte) bool) error
+}
+
+// keySeparator joins the parts of a key. It is not a Base64 character, so a key splits unambiguously and
+// the keys beginning with PreKey belong to that prefix alone. It sorts after '-' and before the other
+// Base64 characters, so keys are grouped in prefix order only among prefixes of the same length, which
+// prefixes of the same code are.
+const keySeparator = '.'
+
+// SnKey keys an event by the prefix and sequence number of its identifier. The sequence number is
+// zero padded hex, so the events of a prefix iterate in order.
+func SnKey(pre string, sn uint64) []byte {
+	return fmt.Appendf(nil, "%s%c%032x", pre, keySeparator, sn)
+}
+
+// FnKey keys an event by the prefix of its identifier and its first seen ordinal
+func FnKey(pre string, fn uint64) []byte {
+	return SnKey(pre, fn)
+}
+
+// DgKey keys an event by the prefix of its identifier and its digest
+func DgKey(pre, dig string) []byte {
+	return fmt.Appendf(nil, "%s%c%s", pre, keySeparator, dig)
+}
+
+// PreKey is the prefix shared by the keys of pre, for iteration
+func PreKey(pre string) []byte {
+	return fmt.Appendf(nil, "%s%c", pre, keySeparator)
+}
+
+// SplitKey splits a key into its prefix and the digest or hex ordinal that follows it
+func SplitKey(key []byte) (string, string, error) {
+	i := bytes.LastIndexByte(key, keySeparator)
+	if i < 0 {
+		return "", "", fmt.Errorf("missing separator in key: %s", key)
+	}
+
+	return string(key[:i]), string(key[i+1:]), nil
+}
+
+// SplitSnKey splits a key made by SnKey or FnKey into its prefix and ordinal
+func SplitSnKey(key []byte) (string, uint64, error) {
+	pre, hex, err := SplitKey(key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	sn, err := strconv.ParseUint(hex, 16, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid ordinal in key: %s", key)
+	}
+
+	return pre, sn, nil
+}
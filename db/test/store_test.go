@@ -0,0 +1,440 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/jasoncolburne/cesrgo/db"
+)
+
+const storeTestPre = "EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OU"
+
+func storeTestStores(t *testing.T) map[string]db.Store {
+	t.Helper()
+
+	bolt, err := db.NewBoltStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	t.Cleanup(func() { _ = bolt.Close() })
+
+	return map[string]db.Store{
+		"memory": db.NewMemoryStore(),
+		"bolt":   bolt,
+	}
+}
+
+func TestStoreKeys(t *testing.T) {
+	key := db.SnKey(storeTestPre, 10)
+	if string(key) != storeTestPre+".0000000000000000000000000000000a" {
+		t.Fatalf("unexpected sn key: %s", key)
+	}
+
+	if bytes.Compare(db.SnKey(storeTestPre, 9), key) >= 0 || !bytes.HasPrefix(key, db.PreKey(storeTestPre)) {
+		t.Fatalf("expected sn keys to be ordered")
+	}
+
+	pre, sn, err := db.SplitSnKey(db.FnKey(storeTestPre, 0x1f))
+	if err != nil || pre != storeTestPre || sn != 0x1f {
+		t.Fatalf("unexpected split: %s %d (%v)", pre, sn, err)
+	}
+
+	pre, dig, err := db.SplitKey(db.DgKey(storeTestPre, "EAdig"))
+	if err != nil || pre != storeTestPre || dig != "EAdig" {
+		t.Fatalf("unexpected split: %s %s (%v)", pre, dig, err)
+	}
+
+	if _, _, err := db.SplitSnKey(db.DgKey(storeTestPre, "EAdig")); err == nil {
+		t.Fatalf("expected digest key to be rejected as sn key")
+	}
+}
+
+func TestStoreKeyOrdering(t *testing.T) {
+	// prefixes of the same length, ordered, and one that extends the first with '-', which sorts before
+	// the separator
+	pres := []string{
+		"BAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+		storeTestPre,
+		"EHJq2PWESIo1D4z3ca3ve7UKpwZ4uzmp-LCV5VO9v7OV",
+	}
+	extended := storeTestPre + "-"
+
+	for name, store := range storeTestStores(t) {
+		err := store.Update(func(tx db.Tx) error {
+			for _, pre := range append([]string{extended}, pres...) {
+				for _, n := range []uint64{0x10, 2, 0xff, 0} {
+					if err := tx.Put(db.Bucket_KELs, db.SnKey(pre, n), fmt.Appendf(nil, "%s:%d", pre, n)); err != nil {
+						return err
+					}
+
+					if err := tx.Put(db.Bucket_FirstSeen, db.FnKey(pre, n), fmt.Appendf(nil, "%s:%d", pre, n)); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to put: %v", name, err)
+		}
+
+		err = store.View(func(tx db.Tx) error {
+			for _, bucket := range []db.Bucket{db.Bucket_KELs, db.Bucket_FirstSeen} {
+				// a prefix iterates alone, in ordinal order
+				vals := []string{}
+				err := tx.Iterate(bucket, db.PreKey(storeTestPre), func(_, val []byte) bool {
+					vals = append(vals, string(val))
+					return true
+				})
+
+				expected := []string{}
+				for _, n := range []uint64{0, 2, 0x10, 0xff} {
+					expected = append(expected, fmt.Sprintf("%s:%d", storeTestPre, n))
+				}
+
+				if err != nil || !slices.Equal(vals, expected) {
+					t.Fatalf("%s: unexpected iteration of %s: %v (%v)", name, bucket, vals, err)
+				}
+
+				// prefixes of the same length are grouped in prefix order
+				keys := []string{}
+				err = tx.Iterate(bucket, nil, func(key, _ []byte) bool {
+					pre, n, err := db.SplitSnKey(key)
+					if err != nil {
+						t.Fatalf("%s: failed to split: %v", name, err)
+					}
+
+					if len(pre) == len(storeTestPre) {
+						keys = append(keys, fmt.Sprintf("%s:%d", pre, n))
+					}
+
+					return true
+				})
+
+				expected = []string{}
+				for _, pre := range pres {
+					for _, n := range []uint64{0, 2, 0x10, 0xff} {
+						expected = append(expected, fmt.Sprintf("%s:%d", pre, n))
+					}
+				}
+
+				if err != nil || !slices.Equal(keys, expected) {
+					t.Fatalf("%s: unexpected order of %s: %v (%v)", name, bucket, keys, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to view: %v", name, err)
+		}
+	}
+}
+
+func TestStoreVals(t *testing.T) {
+	for name, store := range storeTestStores(t) {
+		err := store.Update(func(tx db.Tx) error {
+			for _, sn := range []uint64{2, 0, 1} {
+				if err := tx.Put(db.Bucket_KELs, db.SnKey(storeTestPre, sn), fmt.Appendf(nil, "dig%d", sn)); err != nil {
+					return err
+				}
+			}
+
+			return tx.Put(db.Bucket_KELs, db.SnKey("BOther", 0), []byte("other"))
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to put: %v", name, err)
+		}
+
+		err = store.View(func(tx db.Tx) error {
+			val, err := tx.Get(db.Bucket_KELs, db.SnKey(storeTestPre, 1))
+			if err != nil || string(val) != "dig1" {
+				t.Fatalf("%s: unexpected value: %s (%v)", name, val, err)
+			}
+
+			if val, err := tx.Get(db.Bucket_States, []byte(storeTestPre)); err != nil || val != nil {
+				t.Fatalf("%s: expected missing value", name)
+			}
+
+			vals := []string{}
+			err = tx.Iterate(db.Bucket_KELs, db.PreKey(storeTestPre), func(_, val []byte) bool {
+				vals = append(vals, string(val))
+				return true
+			})
+			if err != nil || !slices.Equal(vals, []string{"dig0", "dig1", "dig2"}) {
+				t.Fatalf("%s: unexpected iteration: %v (%v)", name, vals, err)
+			}
+
+			if err := tx.Put(db.Bucket_KELs, []byte("key"), nil); err == nil {
+				t.Fatalf("%s: expected write in read only transaction to be rejected", name)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to view: %v", name, err)
+		}
+
+		// a failed transaction is rolled back
+		err = store.Update(func(tx db.Tx) error {
+			if err := tx.Delete(db.Bucket_KELs, db.SnKey(storeTestPre, 0)); err != nil {
+				return err
+			}
+
+			return fmt.Errorf("abort")
+		})
+		if err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+
+		err = store.View(func(tx db.Tx) error {
+			val, err := tx.Get(db.Bucket_KELs, db.SnKey(storeTestPre, 0))
+			if err != nil || string(val) != "dig0" {
+				t.Fatalf("%s: expected delete to be rolled back", name)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to view: %v", name, err)
+		}
+	}
+}
+
+func TestStoreSets(t *testing.T) {
+	for name, store := range storeTestStores(t) {
+		key := db.DgKey(storeTestPre, "EAdig")
+
+		err := store.Update(func(tx db.Tx) error {
+			for _, val := range []string{"sig1", "sig0", "sig1", "sig2"} {
+				if _, err := tx.AddVal(db.Bucket_Sigs, key, []byte(val)); err != nil {
+					return err
+				}
+			}
+
+			if added, err := tx.AddVal(db.Bucket_Sigs, key, []byte("sig0")); err != nil || added {
+				t.Fatalf("%s: expected duplicate not to be added", name)
+			}
+
+			if _, err := tx.AddVal(db.Bucket_Sigs, db.DgKey("BOther", "EAdig"), []byte("other")); err != nil {
+				return err
+			}
+
+			return tx.DeleteVal(db.Bucket_Sigs, key, []byte("sig0"))
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to add: %v", name, err)
+		}
+
+		err = store.View(func(tx db.Tx) error {
+			vals, err := tx.Vals(db.Bucket_Sigs, key)
+			if err != nil || len(vals) != 2 || string(vals[0]) != "sig1" || string(vals[1]) != "sig2" {
+				t.Fatalf("%s: expected insertion order: %s (%v)", name, vals, err)
+			}
+
+			count := 0
+			err = tx.IterateVals(db.Bucket_Sigs, db.PreKey(storeTestPre), func(k, _ []byte) bool {
+				if !bytes.Equal(k, key) {
+					t.Fatalf("%s: unexpected key: %s", name, k)
+				}
+
+				count++
+
+				return true
+			})
+			if err != nil || count != 2 {
+				t.Fatalf("%s: unexpected iteration: %d (%v)", name, count, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to view: %v", name, err)
+		}
+
+		err = store.Update(func(tx db.Tx) error {
+			if err := tx.DeleteVals(db.Bucket_Sigs, key); err != nil {
+				return err
+			}
+
+			vals, err := tx.Vals(db.Bucket_Sigs, key)
+			if err != nil || len(vals) != 0 {
+				t.Fatalf("%s: expected empty set", name)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to delete: %v", name, err)
+		}
+	}
+}
+
+// storeTestCollisions writes a value and a set at the same keys and describes what each backend then
+// reports, so the backends can be compared
+func storeTestCollisions(t *testing.T, store db.Store) []string {
+	t.Helper()
+
+	valKey := db.DgKey(storeTestPre, "EAval")
+	setKey := db.DgKey(storeTestPre, "EAset")
+	outcomes := []string{}
+
+	err := store.Update(func(tx db.Tx) error {
+		if err := tx.Put(db.Bucket_Events, valKey, []byte("val")); err != nil {
+			return err
+		}
+
+		_, err := tx.AddVal(db.Bucket_Events, setKey, []byte("set"))
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+
+	// each write is attempted in its own transaction, which a failure rolls back
+	writes := map[string]func(tx db.Tx) error{
+		"put on set": func(tx db.Tx) error { return tx.Put(db.Bucket_Events, setKey, []byte("val")) },
+		"add to val": func(tx db.Tx) error {
+			_, err := tx.AddVal(db.Bucket_Events, valKey, []byte("set"))
+			return err
+		},
+		"delete set":        func(tx db.Tx) error { return tx.Delete(db.Bucket_Events, setKey) },
+		"delete val of val": func(tx db.Tx) error { return tx.DeleteVal(db.Bucket_Events, valKey, []byte("val")) },
+		"delete vals of val": func(tx db.Tx) error {
+			return tx.DeleteVals(db.Bucket_Events, valKey)
+		},
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(writes)) {
+		err := store.Update(writes[name])
+		outcomes = append(outcomes, fmt.Sprintf("%s: failed = %t", name, err != nil))
+	}
+
+	err = store.View(func(tx db.Tx) error {
+		for _, key := range [][]byte{valKey, setKey} {
+			val, err := tx.Get(db.Bucket_Events, key)
+			if err != nil {
+				return err
+			}
+
+			vals, err := tx.Vals(db.Bucket_Events, key)
+			if err != nil {
+				return err
+			}
+
+			outcomes = append(outcomes, fmt.Sprintf("%s: get = %q, vals = %q", key, val, vals))
+		}
+
+		keys := []string{}
+		err := tx.Iterate(db.Bucket_Events, db.PreKey(storeTestPre), func(key, _ []byte) bool {
+			keys = append(keys, string(key))
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		setKeys := []string{}
+		err = tx.IterateVals(db.Bucket_Events, db.PreKey(storeTestPre), func(key, _ []byte) bool {
+			setKeys = append(setKeys, string(key))
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		outcomes = append(outcomes, fmt.Sprintf("iterate = %q, iterate vals = %q", keys, setKeys))
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to view: %v", err)
+	}
+
+	return outcomes
+}
+
+func TestStoreValueSetCollision(t *testing.T) {
+	stores := storeTestStores(t)
+
+	memory := storeTestCollisions(t, stores["memory"])
+	bolt := storeTestCollisions(t, stores["bolt"])
+
+	if !slices.Equal(memory, bolt) {
+		t.Fatalf("backends differ:\nmemory: %q\nbolt:   %q", memory, bolt)
+	}
+
+	valKey := string(db.DgKey(storeTestPre, "EAval"))
+	setKey := string(db.DgKey(storeTestPre, "EAset"))
+
+	expected := []string{
+		"add to val: failed = true",
+		"delete set: failed = true",
+		"delete val of val: failed = false",
+		"delete vals of val: failed = false",
+		"put on set: failed = true",
+		fmt.Sprintf("%s: get = \"val\", vals = []", valKey),
+		fmt.Sprintf("%s: get = \"\", vals = [\"set\"]", setKey),
+		fmt.Sprintf("iterate = [%q], iterate vals = [%q]", valKey, setKey),
+	}
+
+	if !slices.Equal(memory, expected) {
+		t.Fatalf("unexpected outcomes: %q", memory)
+	}
+}
+
+func TestStoreBoltPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	store, err := db.NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	err = store.Update(func(tx db.Tx) error {
+		if err := tx.Put(db.Bucket_States, []byte(storeTestPre), []byte("state")); err != nil {
+			return err
+		}
+
+		_, err := tx.AddVal(db.Bucket_OutOfOrder, db.SnKey(storeTestPre, 3), []byte("EAdig"))
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	store, err = db.NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	err = store.View(func(tx db.Tx) error {
+		val, err := tx.Get(db.Bucket_States, []byte(storeTestPre))
+		if err != nil || string(val) != "state" {
+			t.Fatalf("unexpected value: %s (%v)", val, err)
+		}
+
+		vals, err := tx.Vals(db.Bucket_OutOfOrder, db.SnKey(storeTestPre, 3))
+		if err != nil || len(vals) != 1 || string(vals[0]) != "EAdig" {
+			t.Fatalf("unexpected set: %s (%v)", vals, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to view: %v", err)
+	}
+}
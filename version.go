@@ -120,4 +120,10 @@ var (
 	}
 
 	VERSION = VERSION_2_0
+
+	// supported protocol major versions
+	MAJORS = []uint32{
+		VERSION_1_0.Major,
+		VERSION_2_0.Major,
+	}
 )